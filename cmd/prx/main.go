@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
@@ -69,7 +70,7 @@ func main() {
 	if *noCache {
 		client := prx.NewClient(token, opts...)
 		data, err = client.PullRequest(ctx, owner, repo, prNumber)
-		if err != nil {
+		if err != nil && !errors.Is(err, prx.ErrTruncated) {
 			log.Printf("Failed to fetch PR data: %v", err)
 			os.Exit(1)
 		}
@@ -80,11 +81,14 @@ func main() {
 			os.Exit(1)
 		}
 		data, err = client.PullRequest(ctx, owner, repo, prNumber, time.Now())
-		if err != nil {
+		if err != nil && !errors.Is(err, prx.ErrTruncated) {
 			log.Printf("Failed to fetch PR data: %v", err)
 			os.Exit(1)
 		}
 	}
+	if err != nil {
+		log.Printf("Warning: PR data is incomplete: %v", err)
+	}
 
 	encoder := json.NewEncoder(os.Stdout)
 	if err := encoder.Encode(data); err != nil {
@@ -0,0 +1,191 @@
+package prx
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// appJWTLifetime stays under GitHub's ten minute limit for app JWTs.
+	appJWTLifetime = 9 * time.Minute
+	// appClockSkew backdates the JWT so a slightly fast GitHub clock still accepts it.
+	appClockSkew = time.Minute
+	// installationTokenRefresh renews installation tokens this long before they expire.
+	installationTokenRefresh = 5 * time.Minute
+)
+
+// TokenSource supplies the token sent with each GitHub API request.
+// An empty token makes the request anonymous.
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// StaticToken is a TokenSource that always returns the same token, such as a
+// personal access token. It is what NewClient uses for its token argument.
+type StaticToken string
+
+// Token returns the token itself.
+func (t StaticToken) Token(context.Context) (string, error) {
+	return string(t), nil
+}
+
+// TokenSourceFunc adapts a function to a TokenSource.
+type TokenSourceFunc func(ctx context.Context) (string, error)
+
+// Token calls f.
+func (f TokenSourceFunc) Token(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+// WithTokenSource authenticates requests with tokens from ts instead of the
+// static token passed to NewClient.
+func WithTokenSource(ts TokenSource) Option {
+	return func(c *Client) {
+		c.tokenSource = ts
+	}
+}
+
+// WithTokenProvider calls provider before every request for the current token,
+// for credentials that rotate or expire, such as those kept in a secret manager.
+// It takes precedence over the static token passed to NewClient.
+func WithTokenProvider(provider func(context.Context) (string, error)) Option {
+	return WithTokenSource(TokenSourceFunc(provider))
+}
+
+// AppTokenSource authenticates as a GitHub App installation. It signs a JWT
+// with the app's private key, exchanges it for an installation token, and
+// reuses that token until shortly before it expires.
+type AppTokenSource struct {
+	BaseURL    string       // API root; empty means https://api.github.com
+	HTTPClient *http.Client // Client for the token exchange; nil means http.DefaultClient
+
+	appID          int64
+	installationID int64
+	key            *rsa.PrivateKey
+	now            func() time.Time
+
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+}
+
+// NewAppTokenSource returns a TokenSource for the given app installation.
+// privateKeyPEM is the key downloaded from the app's settings page, in PKCS#1 or PKCS#8 form.
+func NewAppTokenSource(appID, installationID int64, privateKeyPEM []byte) (*AppTokenSource, error) {
+	block, _ := pem.Decode(privateKeyPEM)
+	if block == nil {
+		return nil, errors.New("github app private key is not PEM encoded")
+	}
+	key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err != nil {
+		parsed, pkcs8Err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if pkcs8Err != nil {
+			return nil, fmt.Errorf("parsing github app private key: %w", err)
+		}
+		var ok bool
+		if key, ok = parsed.(*rsa.PrivateKey); !ok {
+			return nil, fmt.Errorf("github app private key is %T, want RSA", parsed)
+		}
+	}
+	return &AppTokenSource{appID: appID, installationID: installationID, key: key, now: time.Now}, nil
+}
+
+// Token returns a cached installation token, fetching a new one when it is near expiry.
+func (s *AppTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token != "" && s.now().Before(s.expires.Add(-installationTokenRefresh)) {
+		return s.token, nil
+	}
+
+	jwt, err := s.jwt()
+	if err != nil {
+		return "", err
+	}
+	base := strings.TrimSuffix(s.BaseURL, "/")
+	if base == "" {
+		base = githubAPI
+	}
+	url := fmt.Sprintf("%s/app/installations/%d/access_tokens", base, s.installationID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, http.NoBody)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+jwt)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("User-Agent", defaultUserAgent)
+
+	client := s.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("fetching installation token: %w", err)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if closeErr := resp.Body.Close(); closeErr != nil && err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", fmt.Errorf("reading installation token: %w", err)
+	}
+	if resp.StatusCode != http.StatusCreated {
+		return "", &GitHubAPIError{
+			StatusCode:         resp.StatusCode,
+			Status:             resp.Status,
+			Body:               redactTokens(string(body), jwt),
+			URL:                url,
+			RateLimitRemaining: -1,
+		}
+	}
+
+	var result struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", fmt.Errorf("decoding installation token: %w", err)
+	}
+	if result.Token == "" {
+		return "", fmt.Errorf("%w: installation token response has no token", ErrUnexpectedResponse)
+	}
+	s.token, s.expires = result.Token, result.ExpiresAt
+	return s.token, nil
+}
+
+// jwt signs the short-lived RS256 token that identifies the app itself.
+func (s *AppTokenSource) jwt() (string, error) {
+	now := s.now()
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"RS256","typ":"JWT"}`))
+	claims, err := json.Marshal(map[string]any{
+		"iat": now.Add(-appClockSkew).Unix(),
+		"exp": now.Add(appJWTLifetime).Unix(),
+		"iss": strconv.FormatInt(s.appID, 10),
+	})
+	if err != nil {
+		return "", err
+	}
+	signed := header + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(signed))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("signing github app JWT: %w", err)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig), nil
+}
@@ -0,0 +1,185 @@
+package prx
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAppTokenSource(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	var exchanges int
+	var apiAuth []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/app/installations/42/access_tokens" {
+			apiAuth = append(apiAuth, r.Header.Get("Authorization"))
+			if _, err := w.Write([]byte(`{}`)); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+			}
+			return
+		}
+		if r.Method != http.MethodPost {
+			http.Error(w, "want POST", http.StatusMethodNotAllowed)
+			return
+		}
+		if err := verifyAppJWT(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "), &key.PublicKey, now); err != "" {
+			http.Error(w, err, http.StatusUnauthorized)
+			return
+		}
+		exchanges++
+		w.WriteHeader(http.StatusCreated)
+		body := `{"token":"ghs_installation` + string(rune('0'+exchanges)) + `","expires_at":"` + now.Add(time.Hour).Format(time.RFC3339) + `"}`
+		if _, err := w.Write([]byte(body)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	src, err := NewAppTokenSource(7, 42, keyPEM)
+	if err != nil {
+		t.Fatalf("NewAppTokenSource() error = %v", err)
+	}
+	src.BaseURL = server.URL
+	src.now = func() time.Time { return now }
+
+	client := NewClient("", WithHTTPClient(&http.Client{Transport: &http.Transport{}}), WithBaseURL(server.URL), WithTokenSource(src))
+	get := func() {
+		t.Helper()
+		var v struct{}
+		if _, err := client.github.get(context.Background(), "/rate_limit", &v); err != nil {
+			t.Fatalf("get() error = %v", err)
+		}
+	}
+
+	get()
+	get()
+	// Within five minutes of expiry the token is renewed.
+	now = now.Add(56 * time.Minute)
+	get()
+
+	if exchanges != 2 {
+		t.Errorf("token exchanges = %d, want 2", exchanges)
+	}
+	want := []string{"Bearer ghs_installation1", "Bearer ghs_installation1", "Bearer ghs_installation2"}
+	if strings.Join(apiAuth, ",") != strings.Join(want, ",") {
+		t.Errorf("Authorization headers = %q, want %q", apiAuth, want)
+	}
+}
+
+// verifyAppJWT checks the signature and claims of an app JWT, returning a problem description or "".
+func verifyAppJWT(token string, pub *rsa.PublicKey, now time.Time) string {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "malformed JWT"
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "bad signature encoding"
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], sig); err != nil {
+		return "bad signature"
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "bad claims encoding"
+	}
+	var claims struct {
+		Iat int64  `json:"iat"`
+		Exp int64  `json:"exp"`
+		Iss string `json:"iss"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return "bad claims"
+	}
+	if claims.Iss != "7" || claims.Iat > now.Unix() || claims.Exp <= now.Unix() || claims.Exp-claims.Iat > 600 {
+		return "bad claims"
+	}
+	return ""
+}
+
+func TestNewAppTokenSourceKeys(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		pem     []byte
+		wantErr bool
+	}{
+		{name: "pkcs1", pem: pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})},
+		{name: "pkcs8", pem: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8})},
+		{name: "not pem", pem: []byte("not a key"), wantErr: true},
+		{name: "garbage", pem: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("junk")}), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewAppTokenSource(1, 2, tt.pem)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewAppTokenSource() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWithTokenProvider(t *testing.T) {
+	var got []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get("Authorization"))
+		if _, err := w.Write([]byte(`{}`)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	var calls int
+	provider := func(context.Context) (string, error) {
+		calls++
+		return "rotated-" + string(rune('0'+calls)), nil
+	}
+	client := newTestClient(t, server, WithTokenProvider(provider))
+	for range 2 {
+		var v struct{}
+		if _, err := client.github.get(context.Background(), "/rate_limit", &v); err != nil {
+			t.Fatalf("get() error = %v", err)
+		}
+	}
+	want := []string{"Bearer rotated-1", "Bearer rotated-2"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("Authorization headers = %q, want %q (static token should lose to the provider)", got, want)
+	}
+
+	failing := newTestClient(t, server, WithTokenProvider(func(context.Context) (string, error) {
+		return "", errors.New("vault sealed")
+	}))
+	var v struct{}
+	if _, err := failing.github.get(context.Background(), "/rate_limit", &v); err == nil || !strings.Contains(err.Error(), "vault sealed") {
+		t.Errorf("get() error = %v, want provider error", err)
+	}
+	if len(got) != 2 {
+		t.Errorf("request sent despite provider error")
+	}
+}
@@ -0,0 +1,90 @@
+package prx
+
+import (
+	"context"
+	"errors"
+)
+
+// BatchProgress records how far a batch fetch has progressed.
+// It is safe to encode as JSON so that long runs can be resumed after a restart.
+type BatchProgress struct {
+	Completed []int          `json:"completed"`        // PR numbers fetched successfully
+	Pending   []int          `json:"pending"`          // PR numbers not yet attempted
+	Failed    map[int]string `json:"failed,omitempty"` // PR number -> error message from the last attempt
+}
+
+// PullRequests fetches several pull requests from the same repository, one at a time.
+//
+// progress may be nil. When it holds a previously saved state, pull requests listed
+// as completed are skipped; failed ones are attempted again. progress is updated in
+// place after each pull request and, if onProgress is non-nil, handed to it so the
+// caller can persist it. Pull requests truncated by a limit (see ErrTruncated) are
+// returned and counted as completed. If ctx is cancelled, the results gathered so
+// far are returned along with the context error, and unattempted pull requests
+// remain pending.
+func (c *Client) PullRequests(ctx context.Context, owner, repo string, numbers []int,
+	progress *BatchProgress, onProgress func(*BatchProgress),
+) (map[int]*PullRequestData, error) {
+	if progress == nil {
+		progress = &BatchProgress{}
+	}
+	if progress.Failed == nil {
+		progress.Failed = make(map[int]string)
+	}
+
+	// Completed and already queued numbers are skipped; a set keeps this linear in the batch size.
+	skip := make(map[int]struct{}, len(progress.Completed)+len(numbers))
+	for _, n := range progress.Completed {
+		skip[n] = struct{}{}
+	}
+	pending := make([]int, 0, len(numbers))
+	for _, n := range numbers {
+		if _, ok := skip[n]; !ok {
+			skip[n] = struct{}{}
+			pending = append(pending, n)
+		}
+	}
+	progress.Pending = pending
+
+	c.logger.InfoContext(ctx, "fetching pull request batch",
+		"owner", owner,
+		"repo", repo,
+		"requested", len(numbers),
+		"pending", len(pending),
+		"skipped", len(numbers)-len(pending))
+
+	results := make(map[int]*PullRequestData, len(pending))
+	for len(progress.Pending) > 0 {
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+
+		n := progress.Pending[0]
+		data, err := c.PullRequest(ctx, owner, repo, n)
+		if err != nil && ctx.Err() != nil {
+			// Interrupted: leave n pending so a resumed run picks it up.
+			return results, ctx.Err()
+		}
+
+		progress.Pending = progress.Pending[1:]
+		if errors.Is(err, ErrTruncated) {
+			// A configured limit cut the results short; keep what was fetched.
+			c.logger.WarnContext(ctx, "batch fetch truncated for pull request", "pr", n, "error", err)
+			err = nil
+		}
+		if err != nil {
+			c.logger.WarnContext(ctx, "batch fetch failed for pull request", "pr", n, "error", err)
+			progress.Failed[n] = err.Error()
+		} else {
+			delete(progress.Failed, n)
+			progress.Completed = append(progress.Completed, n)
+			results[n] = data
+		}
+
+		if onProgress != nil {
+			onProgress(progress)
+		}
+	}
+
+	return results, nil
+}
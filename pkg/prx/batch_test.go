@@ -0,0 +1,74 @@
+package prx
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestPullRequestsResume(t *testing.T) {
+	pr := func(n int) githubPullRequest {
+		return githubPullRequest{
+			Number:    n,
+			State:     "open",
+			CreatedAt: githubTime{Time: time.Now().Add(-time.Hour)},
+			User:      &githubUser{Login: "author"},
+		}
+	}
+	mock := &mockGithubClient{
+		responses: map[string]any{
+			"/repos/owner/repo/pulls/2": pr(2),
+			"/repos/owner/repo/pulls/3": pr(3),
+		},
+	}
+	client := &Client{
+		github:          mock,
+		logger:          slog.Default(),
+		permissionCache: &permissionCache{memory: make(map[string]permissionEntry)},
+	}
+
+	// Simulate a progress file saved by an interrupted run.
+	var saved BatchProgress
+	if err := json.Unmarshal([]byte(`{"completed":[1],"pending":[2,3]}`), &saved); err != nil {
+		t.Fatalf("unmarshal progress: %v", err)
+	}
+
+	var updates int
+	results, err := client.PullRequests(context.Background(), "owner", "repo", []int{1, 2, 3, 2}, &saved,
+		func(*BatchProgress) { updates++ })
+	if err != nil {
+		t.Fatalf("PullRequests() error = %v", err)
+	}
+
+	if _, ok := results[1]; ok {
+		t.Error("PR 1 was already completed and should not be fetched again")
+	}
+	for _, n := range []int{2, 3} {
+		if results[n] == nil || results[n].PullRequest.Number != n {
+			t.Errorf("missing result for PR %d", n)
+		}
+	}
+	if slices.Contains(mock.calls, "/repos/owner/repo/pulls/1") {
+		t.Error("completed PR 1 was requested")
+	}
+	if updates != 2 {
+		t.Errorf("expected 2 progress updates, got %d", updates)
+	}
+	if !slices.Equal(saved.Completed, []int{1, 2, 3}) {
+		t.Errorf("completed = %v, want [1 2 3]", saved.Completed)
+	}
+	if len(saved.Pending) != 0 {
+		t.Errorf("pending = %v, want empty", saved.Pending)
+	}
+
+	data, err := json.Marshal(&saved)
+	if err != nil {
+		t.Fatalf("marshal progress: %v", err)
+	}
+	if string(data) != `{"completed":[1,2,3],"pending":[]}` {
+		t.Errorf("unexpected progress JSON: %s", data)
+	}
+}
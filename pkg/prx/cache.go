@@ -44,7 +44,7 @@ func NewCacheClient(token string, cacheDir string, opts ...Option) (*CacheClient
 	client := NewClient(token, opts...)
 
 	// Initialize permission cache with disk persistence for CacheClient
-	permCache, err := newPermissionCache(cleanPath)
+	permCache, err := newPermissionCache(cleanPath, client.permissionTTL)
 	if err != nil {
 		return nil, fmt.Errorf("creating permission cache: %w", err)
 	}
@@ -62,6 +62,8 @@ func NewCacheClient(token string, cacheDir string, opts ...Option) (*CacheClient
 }
 
 // PullRequest fetches a pull request with all its events and metadata, with caching support.
+// Like Client.PullRequest, partial data is returned with an error wrapping ErrTruncated
+// when a limit cuts the results short.
 func (c *CacheClient) PullRequest(ctx context.Context, owner, repo string, prNumber int, referenceTime time.Time) (*PullRequestData, error) {
 	c.logger.InfoContext(ctx, "fetching pull request with cache",
 		"owner", owner,
@@ -77,154 +79,68 @@ func (c *CacheClient) PullRequest(ctx context.Context, owner, repo string, prNum
 
 	var events []Event
 
-	pullRequest := PullRequest{
-		Number:         pr.Number,
-		Title:          pr.Title,
-		Body:           pr.Body,
-		State:          pr.State,
-		Draft:          pr.Draft,
-		Merged:         pr.Merged,
-		Mergeable:      pr.Mergeable,
-		MergeableState: pr.MergeableState,
-		CreatedAt:      pr.CreatedAt,
-		UpdatedAt:      pr.UpdatedAt,
-		Author:         pr.User.Login,
-		AuthorBot:      isBot(pr.User),
-		Additions:      pr.Additions,
-		Deletions:      pr.Deletions,
-		ChangedFiles:   pr.ChangedFiles,
-	}
-
-	// Check if PR author has write access
-	if pr.User != nil {
-		pullRequest.AuthorWriteAccess = c.writeAccess(ctx, owner, repo, pr.User, pr.AuthorAssociation)
-	}
-
-	if !pr.ClosedAt.IsZero() {
-		pullRequest.ClosedAt = &pr.ClosedAt
-	}
-	if !pr.MergedAt.IsZero() {
-		pullRequest.MergedAt = &pr.MergedAt
-	}
-	if pr.MergedBy != nil {
-		pullRequest.MergedBy = pr.MergedBy.Login
-	}
-
-	prOpenedEvent := Event{
-		Kind:        "pr_opened",
-		Timestamp:   pr.CreatedAt,
-		Actor:       pr.User.Login,
-		Bot:         isBot(pr.User),
-		WriteAccess: c.writeAccess(ctx, owner, repo, pr.User, pr.AuthorAssociation),
-	}
-	events = append(events, prOpenedEvent)
-
-	prUpdatedAt := pr.UpdatedAt
-
-	var errors []error
-
-	// Fetch all event types in parallel
-	type result struct {
-		events []Event
-		err    error
-		name   string
-	}
-
-	results := make(chan result, 7)
-
-	go func() {
-		e, err := c.cachedCommits(ctx, owner, repo, prNumber, prUpdatedAt)
-		results <- result{e, err, "commits"}
-	}()
-
-	go func() {
-		e, err := c.cachedComments(ctx, owner, repo, prNumber, prUpdatedAt)
-		results <- result{e, err, "comments"}
-	}()
+	pullRequest := c.pullRequestMetadata(ctx, owner, repo, pr)
 
-	go func() {
-		e, err := c.cachedReviews(ctx, owner, repo, prNumber, prUpdatedAt)
-		results <- result{e, err, "reviews"}
-	}()
+	events = append(events, c.openedEvent(ctx, owner, repo, pr))
 
-	go func() {
-		e, err := c.cachedReviewComments(ctx, owner, repo, prNumber, prUpdatedAt)
-		results <- result{e, err, "review comments"}
-	}()
+	prUpdatedAt := pr.UpdatedAt.Time
 
-	go func() {
-		e, err := c.cachedTimelineEvents(ctx, owner, repo, prNumber, prUpdatedAt)
-		results <- result{e, err, "timeline events"}
-	}()
-
-	go func() {
-		e, err := c.cachedStatusChecks(ctx, owner, repo, pr, prUpdatedAt)
-		results <- result{e, err, "status checks"}
-	}()
-
-	go func() {
-		e, err := c.cachedCheckRuns(ctx, owner, repo, pr, prUpdatedAt)
-		results <- result{e, err, "check runs"}
-	}()
-
-	// Collect results
-	for i := 0; i < 7; i++ {
-		r := <-results
-		if r.err != nil {
-			c.logger.ErrorContext(ctx, "failed to fetch "+r.name, "error", r.err)
-			errors = append(errors, r.err)
-		} else {
-			events = append(events, r.events...)
-		}
-	}
+	fetched, errs := c.fetchSources(ctx, append([]source{
+		{"commits", func() ([]Event, error) { return c.cachedCommits(ctx, owner, repo, prNumber, prUpdatedAt) }},
+		{"comments", func() ([]Event, error) { return c.cachedComments(ctx, owner, repo, prNumber, prUpdatedAt) }},
+		{"reviews", func() ([]Event, error) { return c.cachedReviews(ctx, owner, repo, prNumber, prUpdatedAt) }},
+		{"review comments", func() ([]Event, error) { return c.cachedReviewComments(ctx, owner, repo, prNumber, prUpdatedAt) }},
+		{"timeline events", func() ([]Event, error) { return c.cachedTimelineEvents(ctx, owner, repo, prNumber, prUpdatedAt) }},
+		{"status checks", func() ([]Event, error) { return c.cachedStatusChecks(ctx, owner, repo, pr, prUpdatedAt) }},
+		{"check runs", func() ([]Event, error) { return c.cachedCheckRuns(ctx, owner, repo, pr, prUpdatedAt) }},
+	}, c.linkedIssueSources(ctx, owner, repo, prNumber, pr.Body)...))
+	events = append(events, fetched...)
 
-	if len(events) == 0 && len(errors) > 0 {
-		return nil, fmt.Errorf("failed to fetch any events: %w", errors[0])
+	if len(events) == 0 && len(errs) > 0 {
+		return nil, fmt.Errorf("failed to fetch any events: %w", errs[0])
 	}
 
-	if pr.Merged {
-		mergedEvent := Event{
-			Kind:      "pr_merged",
-			Timestamp: pr.MergedAt,
-		}
-		if pr.MergedBy != nil {
-			mergedEvent.Actor = pr.MergedBy.Login
-			mergedEvent.Bot = isBot(pr.MergedBy)
-		} else {
-			mergedEvent.Actor = "unknown"
-		}
-		events = append(events, mergedEvent)
-	} else if pr.State == "closed" {
-		closedEvent := Event{
-			Kind:        "pr_closed",
-			Timestamp:   pr.ClosedAt,
-			Actor:       pr.User.Login,
-			Bot:         isBot(pr.User),
-			WriteAccess: c.writeAccess(ctx, owner, repo, pr.User, pr.AuthorAssociation),
-		}
-		events = append(events, closedEvent)
+	if closing := c.closingEvent(ctx, owner, repo, pr); closing != nil {
+		events = append(events, *closing)
 	}
 
 	// Filter events to exclude non-failure status_check events
 	events = filterEvents(events)
 
-	sortEventsByTimestamp(events)
+	SortEvents(events)
+	events, truncated := c.limitEvents(ctx, events, errs)
+	if c.groupReviewComments {
+		events = groupByReview(events)
+	}
 
 	// Upgrade write_access from likely (1) to definitely (2) for actors who performed write-access-requiring actions
 	upgradeWriteAccess(events)
 
+	stampTraceID(ctx, events)
+
+	events = c.compactIfDependencyBump(ctx, &pullRequest, events)
+
 	c.logger.InfoContext(ctx, "successfully fetched pull request with cache",
 		"owner", owner,
 		"repo", repo,
 		"pr", prNumber,
 		"event_count", len(events),
-		"cache_hits", len(events)-len(errors),
+		"cache_hits", len(events)-len(errs),
 	)
 
 	return &PullRequestData{
 		PullRequest: pullRequest,
 		Events:      events,
-	}, nil
+	}, truncated
+}
+
+// commentDataType keeps comment pages fetched with rendered HTML apart in the
+// cache from plain ones, since the response bodies differ.
+func (c *CacheClient) commentDataType(dataType string) string {
+	if c.renderedBodies {
+		return dataType + "_html"
+	}
+	return dataType
 }
 
 // cachedPullRequest fetches a pull request with caching.
@@ -235,7 +151,7 @@ func (c *CacheClient) cachedPullRequest(ctx context.Context, owner, repo string,
 	if c.loadCache(cacheKey, &cached) {
 		if cached.CachedAt.After(referenceTime) || cached.CachedAt.Equal(referenceTime) {
 			var pr githubPullRequest
-			if err := json.Unmarshal(cached.Data, &pr); err != nil {
+			if err := decodeJSON(ctx, c.logger, cached.Data, &pr); err != nil {
 				c.logger.WarnContext(ctx, "failed to unmarshal cached pull request", "error", err)
 			} else {
 				c.logger.InfoContext(ctx, "cache hit: pull request",
@@ -271,14 +187,17 @@ func (c *CacheClient) cachedPullRequest(ctx context.Context, owner, repo string,
 	}
 
 	var pr githubPullRequest
-	if err := json.Unmarshal(rawData, &pr); err != nil {
+	if err := decodeJSON(ctx, c.logger, rawData, &pr); err != nil {
 		return nil, fmt.Errorf("unmarshaling pull request: %w", err)
 	}
+	if err := checkPullRequestNumber(&pr, prNumber); err != nil {
+		return nil, err
+	}
 
 	cached = cacheEntry{
 		Data:      rawData,
 		CachedAt:  time.Now(),
-		UpdatedAt: pr.UpdatedAt,
+		UpdatedAt: pr.UpdatedAt.Time,
 	}
 	if err := c.saveCache(cacheKey, cached); err != nil {
 		c.logger.WarnContext(ctx, "failed to save pull request to cache", "error", err)
@@ -336,32 +255,22 @@ func (c *CacheClient) cachedCommits(ctx context.Context, owner, repo string, prN
 		}
 
 		var commits []*githubPullRequestCommit
-		if err := json.Unmarshal(rawData, &commits); err != nil {
+		if err := decodeJSON(ctx, c.logger, rawData, &commits); err != nil {
 			return nil, fmt.Errorf("unmarshaling commits: %w", err)
 		}
 
 		// Process commits into events
 		for _, commit := range commits {
-			event := Event{
-				Kind:      "commit",
-				Timestamp: commit.Commit.Author.Date,
-				Body:      truncate(commit.Commit.Message, 256),
-			}
-
-			if commit.Author != nil {
-				event.Actor = commit.Author.Login
-				event.Bot = isBot(commit.Author)
-			} else {
-				event.Actor = "unknown"
-			}
-
-			allEvents = append(allEvents, event)
+			allEvents = append(allEvents, c.commitEvent(ctx, owner, repo, commit))
 		}
 
 		// Check if there are more pages - if we got less than maxPerPage, we're done
 		if len(commits) < maxPerPage {
 			break
 		}
+		if err := c.pageLimit(ctx, path, page); err != nil {
+			return allEvents, err
+		}
 		page++
 	}
 
@@ -377,26 +286,29 @@ func (c *CacheClient) cachedComments(ctx context.Context, owner, repo string, pr
 		path := fmt.Sprintf("/repos/%s/%s/issues/%d/comments?page=%d&per_page=%d",
 			owner, repo, prNumber, page, maxPerPage)
 
-		rawData, err := c.cachedFetch(ctx, "comments", path, referenceTime)
+		rawData, err := c.cachedFetch(c.commentContext(ctx), c.commentDataType("comments"), path, referenceTime)
 		if err != nil {
 			return nil, err
 		}
 
 		var comments []*githubComment
-		if err := json.Unmarshal(rawData, &comments); err != nil {
+		if err := decodeJSON(ctx, c.logger, rawData, &comments); err != nil {
 			return nil, fmt.Errorf("unmarshaling comments: %w", err)
 		}
 
+		// Cached pages hold every comment, so WithSince is applied here rather than by GitHub.
 		for _, comment := range comments {
-			event := createEvent("comment", comment.CreatedAt, comment.User, comment.Body)
-			event.WriteAccess = c.writeAccess(ctx, owner, repo, comment.User, comment.AuthorAssociation)
-
-			allEvents = append(allEvents, event)
+			if c.updatedSince(comment.UpdatedAt.Time) {
+				allEvents = append(allEvents, c.commentEvent(ctx, owner, repo, comment))
+			}
 		}
 
 		if len(comments) < maxPerPage {
 			break
 		}
+		if err := c.pageLimit(ctx, path, page); err != nil {
+			return allEvents, err
+		}
 		page++
 	}
 
@@ -418,7 +330,7 @@ func (c *CacheClient) cachedReviews(ctx context.Context, owner, repo string, prN
 		}
 
 		var reviews []*githubReview
-		if err := json.Unmarshal(rawData, &reviews); err != nil {
+		if err := decodeJSON(ctx, c.logger, rawData, &reviews); err != nil {
 			return nil, fmt.Errorf("unmarshaling reviews: %w", err)
 		}
 
@@ -429,17 +341,16 @@ func (c *CacheClient) cachedReviews(ctx context.Context, owner, repo string, prN
 					"author_association", review.AuthorAssociation,
 					"state", review.State)
 
-				event := createEvent("review", review.SubmittedAt, review.User, review.Body)
-				event.Outcome = review.State // "approved", "changes_requested", "commented"
-				event.WriteAccess = c.writeAccess(ctx, owner, repo, review.User, review.AuthorAssociation)
-
-				allEvents = append(allEvents, event)
+				allEvents = append(allEvents, c.reviewEvent(ctx, owner, repo, review))
 			}
 		}
 
 		if len(reviews) < maxPerPage {
 			break
 		}
+		if err := c.pageLimit(ctx, path, page); err != nil {
+			return allEvents, err
+		}
 		page++
 	}
 
@@ -455,26 +366,28 @@ func (c *CacheClient) cachedReviewComments(ctx context.Context, owner, repo stri
 		path := fmt.Sprintf("/repos/%s/%s/pulls/%d/comments?page=%d&per_page=%d",
 			owner, repo, prNumber, page, maxPerPage)
 
-		rawData, err := c.cachedFetch(ctx, "review_comments", path, referenceTime)
+		rawData, err := c.cachedFetch(c.commentContext(ctx), c.commentDataType("review_comments"), path, referenceTime)
 		if err != nil {
 			return nil, err
 		}
 
 		var comments []*githubReviewComment
-		if err := json.Unmarshal(rawData, &comments); err != nil {
+		if err := decodeJSON(ctx, c.logger, rawData, &comments); err != nil {
 			return nil, fmt.Errorf("unmarshaling review comments: %w", err)
 		}
 
 		for _, comment := range comments {
-			event := createEvent("review_comment", comment.CreatedAt, comment.User, comment.Body)
-			event.WriteAccess = c.writeAccess(ctx, owner, repo, comment.User, comment.AuthorAssociation)
-
-			allEvents = append(allEvents, event)
+			if c.updatedSince(comment.UpdatedAt.Time) {
+				allEvents = append(allEvents, c.reviewCommentEvent(ctx, owner, repo, comment))
+			}
 		}
 
 		if len(comments) < maxPerPage {
 			break
 		}
+		if err := c.pageLimit(ctx, path, page); err != nil {
+			return allEvents, err
+		}
 		page++
 	}
 
@@ -491,12 +404,18 @@ func (c *CacheClient) cachedTimelineEvents(ctx context.Context, owner, repo stri
 			owner, repo, prNumber, page, maxPerPage)
 
 		rawData, err := c.cachedFetch(ctx, "timeline", path, referenceTime)
+		if isUnsupportedMediaType(err) && page == 1 {
+			// Some GitHub Enterprise versions still gate the timeline behind a preview media type.
+			c.logger.InfoContext(ctx, "timeline rejected default media type, retrying with preview", "accept", timelinePreviewAccept)
+			ctx = withAccept(ctx, timelinePreviewAccept)
+			rawData, err = c.cachedFetch(ctx, "timeline", path, referenceTime)
+		}
 		if err != nil {
 			return nil, err
 		}
 
 		var timelineEvents []*githubTimelineEvent
-		if err := json.Unmarshal(rawData, &timelineEvents); err != nil {
+		if err := decodeJSON(ctx, c.logger, rawData, &timelineEvents); err != nil {
 			return nil, fmt.Errorf("unmarshaling timeline events: %w", err)
 		}
 
@@ -509,11 +428,11 @@ func (c *CacheClient) cachedTimelineEvents(ctx context.Context, owner, repo stri
 				}
 				event = Event{
 					Kind:        te.Event,
-					Timestamp:   te.CreatedAt,
+					Timestamp:   te.CreatedAt.Time,
 					Actor:       te.Actor.Login,
-					Bot:         isBot(te.Actor),
+					Bot:         c.isBot(te.Actor),
 					Target:      te.Assignee.Login,
-					TargetIsBot: isBot(te.Assignee),
+					TargetIsBot: c.isBot(te.Assignee),
 				}
 			case "review_requested", "review_request_removed":
 				if te.Actor == nil {
@@ -522,18 +441,18 @@ func (c *CacheClient) cachedTimelineEvents(ctx context.Context, owner, repo stri
 				if te.RequestedReviewer != nil {
 					event = Event{
 						Kind:        te.Event,
-						Timestamp:   te.CreatedAt,
+						Timestamp:   te.CreatedAt.Time,
 						Actor:       te.Actor.Login,
-						Bot:         isBot(te.Actor),
+						Bot:         c.isBot(te.Actor),
 						Target:      te.RequestedReviewer.Login,
-						TargetIsBot: isBot(te.RequestedReviewer),
+						TargetIsBot: c.isBot(te.RequestedReviewer),
 					}
 				} else if te.RequestedTeam.Name != "" {
 					event = Event{
 						Kind:      te.Event,
-						Timestamp: te.CreatedAt,
+						Timestamp: te.CreatedAt.Time,
 						Actor:     te.Actor.Login,
-						Bot:       isBot(te.Actor),
+						Bot:       c.isBot(te.Actor),
 						Target:    te.RequestedTeam.Name,
 					}
 				} else {
@@ -544,11 +463,13 @@ func (c *CacheClient) cachedTimelineEvents(ctx context.Context, owner, repo stri
 					continue
 				}
 				event = Event{
-					Kind:      te.Event,
-					Timestamp: te.CreatedAt,
-					Actor:     te.Actor.Login,
-					Bot:       isBot(te.Actor),
-					Body:      te.Label.Name, // Store label name in Body field
+					Kind:             te.Event,
+					Timestamp:        te.CreatedAt.Time,
+					Actor:            te.Actor.Login,
+					Bot:              c.isBot(te.Actor),
+					Target:           te.Label.Name,
+					LabelColor:       te.Label.Color,
+					LabelDescription: te.Label.Description,
 				}
 			case "mentioned":
 				if te.Actor == nil {
@@ -556,9 +477,9 @@ func (c *CacheClient) cachedTimelineEvents(ctx context.Context, owner, repo stri
 				}
 				event = Event{
 					Kind:      te.Event,
-					Timestamp: te.CreatedAt,
+					Timestamp: te.CreatedAt.Time,
 					Actor:     te.Actor.Login,
-					Bot:       isBot(te.Actor),
+					Bot:       c.isBot(te.Actor),
 				}
 			case "convert_to_draft", "ready_for_review":
 				if te.Actor == nil {
@@ -566,9 +487,65 @@ func (c *CacheClient) cachedTimelineEvents(ctx context.Context, owner, repo stri
 				}
 				event = Event{
 					Kind:      te.Event,
-					Timestamp: te.CreatedAt,
+					Timestamp: te.CreatedAt.Time,
+					Actor:     te.Actor.Login,
+					Bot:       c.isBot(te.Actor),
+				}
+			case EventKindRenamed:
+				if te.Actor == nil {
+					continue
+				}
+				event = Event{
+					Kind:        te.Event,
+					Timestamp:   te.CreatedAt.Time,
+					Actor:       te.Actor.Login,
+					Bot:         c.isBot(te.Actor),
+					Body:        te.Rename.To,
+					RenamedFrom: te.Rename.From,
+				}
+			case EventKindReviewDismissed:
+				if te.Actor == nil {
+					continue
+				}
+				event = Event{
+					Kind:      te.Event,
+					Timestamp: te.CreatedAt.Time,
+					Actor:     te.Actor.Login,
+					Bot:       c.isBot(te.Actor),
+					Outcome:   reviewOutcome(te.DismissedReview.State),
+					Body:      c.truncateBody(te.DismissedReview.DismissalMessage),
+					ReviewID:  te.DismissedReview.ReviewID,
+				}
+			case EventKindCrossReferenced, EventKindReferenced:
+				if te.Actor == nil {
+					continue
+				}
+				parsed := c.parseTimelineEvent(ctx, owner, repo, te)
+				if parsed == nil {
+					continue
+				}
+				event = *parsed
+			case EventKindAutoMergeEnabled, EventKindAutoMergeDisabled:
+				if te.Actor == nil {
+					continue
+				}
+				event = Event{
+					Kind:      te.Event,
+					Timestamp: te.CreatedAt.Time,
+					Actor:     te.Actor.Login,
+					Bot:       c.isBot(te.Actor),
+					Body:      te.AutoMerge.MergeMethod,
+				}
+			case EventKindHeadRefForcePushed, EventKindHeadRefDeleted, EventKindHeadRefRestored:
+				if te.Actor == nil {
+					continue
+				}
+				event = Event{
+					Kind:      te.Event,
+					Timestamp: te.CreatedAt.Time,
 					Actor:     te.Actor.Login,
-					Bot:       isBot(te.Actor),
+					Bot:       c.isBot(te.Actor),
+					SHA:       te.CommitID,
 				}
 			default:
 				continue
@@ -580,6 +557,9 @@ func (c *CacheClient) cachedTimelineEvents(ctx context.Context, owner, repo stri
 		if len(timelineEvents) < maxPerPage {
 			break
 		}
+		if err := c.pageLimit(ctx, path, page); err != nil {
+			return allEvents, err
+		}
 		page++
 	}
 
@@ -588,7 +568,12 @@ func (c *CacheClient) cachedTimelineEvents(ctx context.Context, owner, repo stri
 
 // cachedStatusChecks fetches status checks with caching.
 func (c *CacheClient) cachedStatusChecks(ctx context.Context, owner, repo string, pr *githubPullRequest, referenceTime time.Time) ([]Event, error) {
-	var allEvents []Event
+	if c.combinedStatus {
+		return c.cachedCombinedStatusChecks(ctx, owner, repo, pr.Head.SHA, referenceTime)
+	}
+
+	var statuses []*githubStatus
+	var truncated error
 	page := 1
 
 	for {
@@ -600,39 +585,91 @@ func (c *CacheClient) cachedStatusChecks(ctx context.Context, owner, repo string
 			return nil, err
 		}
 
-		var statuses []*githubStatus
-		if err := json.Unmarshal(rawData, &statuses); err != nil {
+		var pageStatuses []*githubStatus
+		if err := decodeJSON(ctx, c.logger, rawData, &pageStatuses); err != nil {
 			return nil, fmt.Errorf("unmarshaling statuses: %w", err)
 		}
+		statuses = append(statuses, pageStatuses...)
 
-		for _, status := range statuses {
-			event := Event{
-				Kind:      "status_check",
-				Timestamp: status.CreatedAt,
-				Actor:     status.Creator.Login,
-				Bot:       isBot(status.Creator),
-				Body:      status.Context, // Store check name in Body
-				Outcome:   status.State,   // Store state in Outcome
-			}
-			// Include description if available
-			if status.Description != "" {
-				event.Body = event.Body + ": " + truncate(status.Description, 256)
-			}
-			allEvents = append(allEvents, event)
+		if len(pageStatuses) < maxPerPage {
+			break
+		}
+		if truncated = c.pageLimit(ctx, path, page); truncated != nil {
+			break
+		}
+		page++
+	}
+
+	if c.latestStatuses {
+		statuses = latestStatuses(statuses)
+	}
+	var allEvents []Event
+	for _, status := range statuses {
+		allEvents = append(allEvents, c.cachedStatusEvent(status))
+	}
+
+	return allEvents, truncated
+}
+
+// cachedCombinedStatusChecks fetches the combined status of a commit with caching, for
+// WithCombinedStatus: the latest status for each context, then the overall state.
+func (c *CacheClient) cachedCombinedStatusChecks(ctx context.Context, owner, repo, sha string, referenceTime time.Time) ([]Event, error) {
+	var all githubCombinedStatus
+	var truncated error
+	page := 1
+
+	for {
+		path := fmt.Sprintf("/repos/%s/%s/commits/%s/status?page=%d&per_page=%d",
+			owner, repo, sha, page, maxPerPage)
+
+		rawData, err := c.cachedFetch(ctx, "combined_status", path, referenceTime)
+		if err != nil {
+			return nil, err
+		}
+
+		var combined githubCombinedStatus
+		if err := decodeJSON(ctx, c.logger, rawData, &combined); err != nil {
+			return nil, fmt.Errorf("unmarshaling combined status: %w", err)
 		}
+		all.State = combined.State
+		all.Statuses = append(all.Statuses, combined.Statuses...)
 
-		if len(statuses) < maxPerPage {
+		if len(combined.Statuses) < maxPerPage {
+			break
+		}
+		if truncated = c.pageLimit(ctx, path, page); truncated != nil {
 			break
 		}
 		page++
 	}
 
-	return allEvents, nil
+	var allEvents []Event
+	for _, status := range all.Statuses {
+		allEvents = append(allEvents, c.cachedStatusEvent(status))
+	}
+	// A commit with no statuses has nothing to summarize; GitHub still reports it as "pending".
+	if len(all.Statuses) > 0 {
+		allEvents = append(allEvents, combinedStatusEvent(&all))
+	}
+
+	return allEvents, truncated
+}
+
+// cachedStatusEvent converts a commit status into an event, with its description
+// appended to the check name.
+func (c *CacheClient) cachedStatusEvent(status *githubStatus) Event {
+	event := c.statusEvent(status)
+	// Include description if available
+	if status.Description != "" {
+		event.Body = event.Body + ": " + c.truncateBody(status.Description)
+	}
+	return event
 }
 
 // cachedCheckRuns fetches check runs with caching.
 func (c *CacheClient) cachedCheckRuns(ctx context.Context, owner, repo string, pr *githubPullRequest, referenceTime time.Time) ([]Event, error) {
-	var allEvents []Event
+	var runs []*githubCheckRun
+	var truncated error
 	page := 1
 
 	for {
@@ -645,33 +682,56 @@ func (c *CacheClient) cachedCheckRuns(ctx context.Context, owner, repo string, p
 		}
 
 		var response githubCheckRuns
-		if err := json.Unmarshal(rawData, &response); err != nil {
+		if err := decodeJSON(ctx, c.logger, rawData, &response); err != nil {
 			return nil, fmt.Errorf("unmarshaling check runs: %w", err)
 		}
-
-		for _, run := range response.CheckRuns {
-			event := Event{
-				Kind:      "check_run",
-				Timestamp: run.CompletedAt,
-				Actor:     "github",
-				Bot:       true,
-				Body:      run.Name,       // Store check name in Body
-				Outcome:   run.Conclusion, // Store conclusion in Outcome
-			}
-			if run.CompletedAt.IsZero() {
-				event.Timestamp = run.StartedAt
-				event.Outcome = run.Status
-			}
-			allEvents = append(allEvents, event)
-		}
+		runs = append(runs, response.CheckRuns...)
 
 		if len(response.CheckRuns) < maxPerPage {
 			break
 		}
+		if truncated = c.pageLimit(ctx, path, page); truncated != nil {
+			break
+		}
 		page++
 	}
 
-	return allEvents, nil
+	if c.latestCheckRuns {
+		runs = latestCheckRuns(runs)
+	}
+	var allEvents []Event
+	for _, run := range runs {
+		event := Event{
+			Kind:         "check_run",
+			Timestamp:    run.CompletedAt.Time,
+			Actor:        "github",
+			Bot:          true,
+			Body:         run.Name,       // Store check name in Body
+			Outcome:      run.Conclusion, // Store conclusion in Outcome
+			URL:          run.url(),
+			ExternalID:   run.ExternalID,
+			CheckSuiteID: run.CheckSuite.ID,
+			Elapsed:      run.elapsed(),
+		}
+		if run.CompletedAt.Time.IsZero() {
+			event.Timestamp = run.StartedAt.Time
+			event.Outcome = run.Status
+		}
+		allEvents = append(allEvents, event)
+	}
+
+	return allEvents, truncated
+}
+
+// pageLimit returns ErrMaxPages when page is the last one WithMaxPages allows.
+// Cached pages carry no next link, so callers only ask after a full page.
+func (c *CacheClient) pageLimit(ctx context.Context, path string, page int) error {
+	if c.maxPages == 0 || page < c.maxPages {
+		return nil
+	}
+	path, _, _ = strings.Cut(path, "?")
+	c.logger.WarnContext(ctx, "page limit reached, results are incomplete", "path", path, "max_pages", c.maxPages)
+	return fmt.Errorf("%w: %s has more than %d pages", ErrMaxPages, path, c.maxPages)
 }
 
 func (c *CacheClient) cacheKey(parts ...string) string {
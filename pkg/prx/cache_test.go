@@ -3,11 +3,15 @@ package prx
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -27,11 +31,11 @@ func TestCacheClient(t *testing.T) {
 				Number:    1,
 				Title:     "Test PR",
 				Body:      "Test body",
-				CreatedAt: time.Now().Add(-24 * time.Hour),
-				UpdatedAt: time.Now().Add(-2 * time.Hour),
+				CreatedAt: githubTime{Time: time.Now().Add(-24 * time.Hour)},
+				UpdatedAt: githubTime{Time: time.Now().Add(-2 * time.Hour)},
 				User:      &githubUser{Login: "testuser"},
 				State:     "closed",
-				ClosedAt:  time.Now().Add(-1 * time.Hour),
+				ClosedAt:  githubTime{Time: time.Now().Add(-1 * time.Hour)},
 			}
 			pr.Head.SHA = "abc123"
 			if err := json.NewEncoder(w).Encode(pr); err != nil {
@@ -42,7 +46,7 @@ func TestCacheClient(t *testing.T) {
 			commit := &githubPullRequestCommit{
 				Author: &githubUser{Login: "testuser"},
 			}
-			commit.Commit.Author.Date = time.Now().Add(-12 * time.Hour)
+			commit.Commit.Author.Date = githubTime{Time: time.Now().Add(-12 * time.Hour)}
 			commit.Commit.Message = "Test commit"
 			if err := json.NewEncoder(w).Encode([]*githubPullRequestCommit{commit}); err != nil {
 				http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -129,6 +133,215 @@ func TestCacheClient(t *testing.T) {
 	}
 }
 
+func TestCacheClientCurrentLabels(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body string
+		switch r.URL.Path {
+		case "/repos/test/repo/pulls/1":
+			body = `{"number": 1, "state": "open", "created_at": "2024-03-01T09:00:00Z", "user": {"login": "author"}, "head": {"sha": "abc123"}}`
+		case "/repos/test/repo/issues/1/timeline":
+			body = `[
+				{"event": "labeled", "created_at": "2024-03-01T09:01:00Z", "actor": {"login": "triager"}, "label": {"name": "bug"}},
+				{"event": "labeled", "created_at": "2024-03-01T09:02:00Z", "actor": {"login": "triager"}, "label": {"name": "wip"}},
+				{"event": "unlabeled", "created_at": "2024-03-01T09:03:00Z", "actor": {"login": "author"}, "label": {"name": "wip"}}
+			]`
+		case "/repos/test/repo/commits/abc123/check-runs":
+			body = `{"check_runs": []}`
+		default:
+			body = "[]"
+		}
+		if _, err := w.Write([]byte(body)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewCacheClient("test-token", t.TempDir(),
+		WithHTTPClient(&http.Client{Transport: &http.Transport{}}), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewCacheClient() error = %v", err)
+	}
+
+	data, err := client.PullRequest(context.Background(), "test", "repo", 1, time.Now())
+	if err != nil {
+		t.Fatalf("PullRequest() error = %v", err)
+	}
+	if got := CurrentLabels(data.Events); !slices.Equal(got, []string{"bug"}) {
+		t.Errorf("CurrentLabels() = %q, want [bug]", got)
+	}
+}
+
+func TestCacheClientSince(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body string
+		switch r.URL.Path {
+		case "/repos/test/repo/pulls/1":
+			body = `{"number": 1, "state": "open", "created_at": "2024-03-01T09:00:00Z", "user": {"login": "author"}, "head": {"sha": "abc123"}}`
+		case "/repos/test/repo/issues/1/comments":
+			body = `[
+				{"body": "old", "created_at": "2024-03-01T09:01:00Z", "updated_at": "2024-03-01T09:01:00Z", "user": {"login": "reviewer"}},
+				{"body": "edited", "created_at": "2024-03-01T09:02:00Z", "updated_at": "2024-03-02T09:00:00Z", "user": {"login": "reviewer"}}
+			]`
+		case "/repos/test/repo/pulls/1/comments":
+			body = `[{"id": 7, "body": "nit", "created_at": "2024-03-01T09:03:00Z", "updated_at": "2024-03-01T09:03:00Z", "user": {"login": "reviewer"}}]`
+		case "/repos/test/repo/commits/abc123/check-runs":
+			body = `{"check_runs": []}`
+		default:
+			body = "[]"
+		}
+		if r.URL.Query().Has("since") {
+			http.Error(w, "cached pages must be fetched whole", http.StatusBadRequest)
+			return
+		}
+		if _, err := w.Write([]byte(body)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name  string
+		since time.Time
+		want  []string
+	}{
+		{name: "no filter", want: []string{"old", "edited", "nit"}},
+		{name: "since", since: time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC), want: []string{"edited"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewCacheClient("test-token", t.TempDir(),
+				WithHTTPClient(&http.Client{Transport: &http.Transport{}}), WithBaseURL(server.URL), WithSince(tt.since))
+			if err != nil {
+				t.Fatalf("NewCacheClient() error = %v", err)
+			}
+
+			data, err := client.PullRequest(context.Background(), "test", "repo", 1, time.Now())
+			if err != nil {
+				t.Fatalf("PullRequest() error = %v", err)
+			}
+			var bodies []string
+			for _, e := range data.Events {
+				if e.Kind == EventKindComment || e.Kind == EventKindReviewComment {
+					bodies = append(bodies, e.Body)
+				}
+			}
+			if !slices.Equal(bodies, tt.want) {
+				t.Errorf("comment bodies = %q, want %q", bodies, tt.want)
+			}
+		})
+	}
+}
+
+func TestCacheClientCombinedStatus(t *testing.T) {
+	var mu sync.Mutex
+	requests := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests[r.URL.Path]++
+		mu.Unlock()
+		var body string
+		switch r.URL.Path {
+		case "/repos/test/repo/pulls/1":
+			body = `{"number": 1, "state": "open", "created_at": "2024-03-01T09:00:00Z", "user": {"login": "author"}, "head": {"sha": "abc123"}}`
+		case "/repos/test/repo/commits/abc123/status":
+			body = `{"state": "failure", "total_count": 1, "statuses": [
+				{"context": "ci/lint", "state": "failure", "created_at": "2024-03-01T09:10:00Z"}
+			]}`
+		case "/repos/test/repo/statuses/abc123":
+			http.Error(w, "the full status list should not be read", http.StatusBadRequest)
+			return
+		case "/repos/test/repo/commits/abc123/check-runs":
+			body = `{"check_runs": []}`
+		default:
+			body = "[]"
+		}
+		if _, err := w.Write([]byte(body)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewCacheClient("test-token", t.TempDir(),
+		WithHTTPClient(&http.Client{Transport: &http.Transport{}}), WithBaseURL(server.URL), WithCombinedStatus())
+	if err != nil {
+		t.Fatalf("NewCacheClient() error = %v", err)
+	}
+
+	reference := time.Now()
+	for range 2 {
+		data, err := client.PullRequest(context.Background(), "test", "repo", 1, reference)
+		if err != nil {
+			t.Fatalf("PullRequest() error = %v", err)
+		}
+		var kinds []string
+		for _, e := range data.Events {
+			if e.Kind == EventKindStatusCheck || e.Kind == EventKindCombinedStatus {
+				kinds = append(kinds, e.Kind+"/"+e.Outcome)
+			}
+		}
+		slices.Sort(kinds)
+		if want := []string{"combined_status/failure", "status_check/failure"}; !slices.Equal(kinds, want) {
+			t.Errorf("status events = %q, want %q", kinds, want)
+		}
+	}
+	if got := requests["/repos/test/repo/commits/abc123/status"]; got != 1 {
+		t.Errorf("fetched the combined status %d times, want 1 and then the cache", got)
+	}
+}
+
+func TestCacheClientMaxPages(t *testing.T) {
+	var mu sync.Mutex
+	var commentPages int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body string
+		switch r.URL.Path {
+		case "/repos/test/repo/pulls/1":
+			body = `{"number": 1, "state": "open", "created_at": "2024-03-01T09:00:00Z", "user": {"login": "author"}, "head": {"sha": "abc123"}}`
+		case "/repos/test/repo/issues/1/comments":
+			// Every page is full, so without a cap the cached loop would never end.
+			mu.Lock()
+			commentPages++
+			mu.Unlock()
+			comments := make([]string, maxPerPage)
+			for i := range comments {
+				comments[i] = `{"body": "hi", "created_at": "2024-03-01T09:01:00Z", "user": {"login": "reviewer"}}`
+			}
+			body = "[" + strings.Join(comments, ",") + "]"
+		case "/repos/test/repo/commits/abc123/check-runs":
+			body = `{"check_runs": []}`
+		default:
+			body = "[]"
+		}
+		if _, err := w.Write([]byte(body)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewCacheClient("test-token", t.TempDir(),
+		WithHTTPClient(&http.Client{Transport: &http.Transport{}}), WithBaseURL(server.URL), WithMaxPages(2))
+	if err != nil {
+		t.Fatalf("NewCacheClient() error = %v", err)
+	}
+
+	data, err := client.PullRequest(context.Background(), "test", "repo", 1, time.Now())
+	if !errors.Is(err, ErrMaxPages) {
+		t.Fatalf("PullRequest() error = %v, want ErrMaxPages", err)
+	}
+	if commentPages != 2 {
+		t.Errorf("fetched %d comment pages, want 2", commentPages)
+	}
+	var comments int
+	for _, e := range data.Events {
+		if e.Kind == EventKindComment {
+			comments++
+		}
+	}
+	if comments != 2*maxPerPage {
+		t.Errorf("got %d comments, want the %d from the pages fetched", comments, 2*maxPerPage)
+	}
+}
+
 func TestCacheKeyGeneration(t *testing.T) {
 	client := &CacheClient{}
 
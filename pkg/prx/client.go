@@ -11,7 +11,11 @@ import (
 	"log/slog"
 	"net/http"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
+
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Client provides methods to fetch GitHub pull request events.
@@ -20,10 +24,106 @@ type Client struct {
 		get(ctx context.Context, path string, v any) (*githubResponse, error)
 		raw(ctx context.Context, path string) (json.RawMessage, *githubResponse, error)
 		userPermission(ctx context.Context, owner, repo, username string) (string, error)
+		graphql(ctx context.Context, query string, variables map[string]any, v any) error
 	}
 	logger          *slog.Logger
 	token           string // Store token for recreating client with new transport
 	permissionCache *permissionCache
+
+	// noWriteAccessLookup resolves write access from author association alone, without permission API calls.
+	noWriteAccessLookup bool
+	// collaboratorListing resolves permissions from one listing of the repository's collaborators.
+	collaboratorListing bool
+	// collaborators holds a *collaboratorList per "owner/repo" once listed.
+	collaborators sync.Map
+
+	// fetchConcurrency bounds how many event sources are fetched at once for a pull request.
+	fetchConcurrency int
+	// permissionSem bounds concurrent permission API lookups; nil means unbounded.
+	permissionSem chan struct{}
+
+	// latestCheckRuns drops superseded attempts of re-run checks.
+	latestCheckRuns bool
+	// latestStatuses drops superseded updates to each commit status context.
+	latestStatuses bool
+	// combinedStatus reads commit statuses from the combined status endpoint.
+	combinedStatus bool
+	// graphQL fetches pull requests with one GraphQL query instead of REST lists.
+	graphQL bool
+	// graphQLKinds limits GraphQL fetches to these event kinds; nil fetches every kind.
+	graphQLKinds map[string]bool
+	// diffHunks enables capturing the diff hunk on review comment events.
+	diffHunks bool
+	// renderedBodies requests GitHub's rendered HTML for comment bodies.
+	renderedBodies bool
+	// groupReviewComments places review comments directly after their review.
+	groupReviewComments bool
+	// maxPages caps the pages fetched per endpoint, defaultMaxPages unless set; 0 means no limit.
+	maxPages int
+	// maxEvents caps the events returned per pull request; 0 means no limit.
+	maxEvents int
+	// since limits comments and review comments to those updated at or after it; zero fetches all.
+	since time.Time
+	// capabilities records what the token may do once Verify has run; nil means unknown.
+	capabilities atomic.Pointer[Capabilities]
+	// reactions keeps the reaction counts GitHub sends with each comment.
+	reactions bool
+	// bodyLimit caps the bytes kept from comment, review, commit, and PR bodies; 0 keeps them whole.
+	bodyLimit int
+	// permissionTTL is how long resolved permissions are reused; 0 keeps the 24h default.
+	permissionTTL time.Duration
+	// baseURL overrides the GitHub API root when set.
+	baseURL string
+	// tokenSource replaces the static token when set.
+	tokenSource TokenSource
+	// userAgent overrides the default "prx/<version>" User-Agent when set.
+	userAgent string
+	// etags enables conditional requests; nil disables them.
+	etags ETagCache
+	// tracer records a span per GitHub API request; nil disables tracing.
+	tracer trace.Tracer
+	// retryAttempts and retryDelay configure the RetryTransport; 0 keeps its defaults.
+	retryAttempts int
+	retryDelay    time.Duration
+	// linkedIssueLimit is how many closing-referenced issues to merge in; 0 disables it.
+	linkedIssueLimit int
+	// botLogins maps lowercased logins to a forced bot (true) or human (false) classification.
+	botLogins map[string]bool
+	// botDetector replaces the default bot heuristic when set.
+	botDetector func(login, userType string) bool
+	// dependencyBots enables dependency bump compaction for PRs opened by these accounts.
+	dependencyBots []string
+}
+
+const (
+	// defaultFetchConcurrency fetches every event source for a pull request at once.
+	defaultFetchConcurrency = 7
+	// defaultPermissionConcurrency keeps permission lookups for busy PRs from bursting.
+	defaultPermissionConcurrency = 5
+	// defaultHTTPTimeout bounds each GitHub request, retries included, unless WithHTTPClient says otherwise.
+	defaultHTTPTimeout = 30 * time.Second
+	// defaultMaxPages guards against a Link header that never stops pointing at
+	// another page; at 100 items a page it still allows 10,000 items per endpoint.
+	defaultMaxPages = 100
+	// defaultBodyLimit is how many bytes of each body are kept unless WithBodyLimit says otherwise.
+	defaultBodyLimit = 256
+	// maxDiffHunkLength caps the diff hunk stored on a review comment event.
+	maxDiffHunkLength = 4096
+)
+
+// isBot reports whether user is a bot, honoring WithBotLogins, then WithBotDetector,
+// before the default heuristic.
+func (c *Client) isBot(user *githubUser) bool {
+	if user == nil {
+		return false
+	}
+	if bot, ok := c.botLogins[strings.ToLower(user.Login)]; ok {
+		return bot
+	}
+	if c.botDetector != nil {
+		return c.botDetector(user.Login, user.Type)
+	}
+	return isBot(user)
 }
 
 // isBot returns true if the user appears to be a bot.
@@ -40,16 +140,52 @@ func isBot(user *githubUser) bool {
 // Option is a function that configures a Client.
 type Option func(*Client)
 
-// WithLogger sets a custom logger for the client.
+// WithLogger sets the logger for the client, including its HTTP requests and retries.
+// Logs are discarded by default. A nil logger is ignored.
 func WithLogger(logger *slog.Logger) Option {
 	return func(c *Client) {
-		c.logger = logger
+		if logger != nil {
+			c.logger = logger
+		}
+	}
+}
+
+// WithBotLogins overrides bot detection for specific accounts: logins in bots are always
+// treated as bots, such as a service account of type User, and logins in humans never are.
+// Matching ignores case. Repeated use adds to the lists.
+func WithBotLogins(bots, humans []string) Option {
+	return func(c *Client) {
+		if c.botLogins == nil {
+			c.botLogins = make(map[string]bool, len(bots)+len(humans))
+		}
+		for _, login := range bots {
+			c.botLogins[strings.ToLower(login)] = true
+		}
+		for _, login := range humans {
+			c.botLogins[strings.ToLower(login)] = false
+		}
+	}
+}
+
+// WithBotDetector replaces the default bot heuristic (a "Bot" user type or a login
+// ending in "[bot]", "-bot", or "-robot") with detect, which is given each login and
+// GitHub's user type ("User", "Bot", "Organization", or empty). Logins listed with
+// WithBotLogins are still classified as listed.
+func WithBotDetector(detect func(login, userType string) bool) Option {
+	return func(c *Client) {
+		c.botDetector = detect
 	}
 }
 
-// WithHTTPClient sets a custom HTTP client.
+// WithHTTPClient sets a custom HTTP client, for proxies, custom TLS, instrumented
+// transports, or a different timeout. Its transport is wrapped in a RetryTransport
+// unless it already is one, in which case its attempts and delay are kept unless
+// WithRetryAttempts or WithRetryDelay is also given. A nil client is ignored.
 func WithHTTPClient(httpClient *http.Client) Option {
 	return func(c *Client) {
+		if httpClient == nil {
+			return
+		}
 		// Wrap the transport with retry logic if not already wrapped
 		if httpClient.Transport == nil {
 			httpClient.Transport = &RetryTransport{Base: http.DefaultTransport}
@@ -60,8 +196,211 @@ func WithHTTPClient(httpClient *http.Client) Option {
 	}
 }
 
+// WithBaseURL points the client at a different GitHub API, such as GitHub Enterprise
+// Server ("https://github.example.com/api/v3"). The default is https://api.github.com.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// WithFetchConcurrency sets how many event sources (commits, comments, reviews,
+// and so on) are fetched in parallel for a single pull request. Values below 1 are ignored.
+func WithFetchConcurrency(n int) Option {
+	return func(c *Client) {
+		if n > 0 {
+			c.fetchConcurrency = n
+		}
+	}
+}
+
+// WithPermissionConcurrency sets how many user permission lookups may be in
+// flight at once. It is independent of WithFetchConcurrency because a PR with
+// many participants needs many more lookups than it has sources. Values below 1 are ignored.
+func WithPermissionConcurrency(n int) Option {
+	return func(c *Client) {
+		if n > 0 {
+			c.permissionSem = make(chan struct{}, n)
+		}
+	}
+}
+
+// WithLatestCheckRuns keeps only the most recent attempt of each check run, by name,
+// so re-run checks appear once with their current result. By default every attempt is
+// kept, giving the full history.
+func WithLatestCheckRuns() Option {
+	return func(c *Client) {
+		c.latestCheckRuns = true
+	}
+}
+
+// WithLatestStatuses keeps only the most recent commit status for each context, as in
+// GitHub's combined status view, so a context that went from pending to success appears
+// once. By default every status update is kept, giving the full history.
+func WithLatestStatuses() Option {
+	return func(c *Client) {
+		c.latestStatuses = true
+	}
+}
+
+// WithCombinedStatus reads commit statuses from GitHub's combined status endpoint
+// instead of the full status list. It yields one status_check event per context, with
+// its latest state, and a combined_status event carrying the commit's overall state.
+// The combined endpoint does not report who set each status, so those events have
+// the actor "unknown". CacheClient caches the combined status pages as well.
+func WithCombinedStatus() Option {
+	return func(c *Client) {
+		c.combinedStatus = true
+	}
+}
+
+// WithDiffHunks captures the diff hunk each review comment is attached to in
+// Event.DiffHunk, truncated to 4 KiB. It is off by default because hunks can be large.
+func WithDiffHunks() Option {
+	return func(c *Client) {
+		c.diffHunks = true
+	}
+}
+
+// WithRenderedBodies stores GitHub's rendered HTML for comments and review comments
+// in Event.BodyHTML, alongside the markdown in Event.Body. The HTML is requested with
+// the "full" media type, so no extra API calls are made, but responses are larger
+// and the HTML is not truncated. Cached responses are kept separately for this mode.
+func WithRenderedBodies() Option {
+	return func(c *Client) {
+		c.renderedBodies = true
+	}
+}
+
+// WithReviewCommentGrouping places each review's inline comments directly after
+// the review event, instead of ordering them purely by timestamp. GitHub stamps a
+// review and its comments a moment apart, so without this other events can land
+// between them.
+func WithReviewCommentGrouping() Option {
+	return func(c *Client) {
+		c.groupReviewComments = true
+	}
+}
+
+// WithMaxPages limits how many pages are fetched from each list endpoint; the default is 100.
+// When an endpoint has more, the events gathered so far are returned with ErrMaxPages.
+// Values below 1 mean no limit.
+func WithMaxPages(n int) Option {
+	return func(c *Client) {
+		c.maxPages = max(n, 0)
+	}
+}
+
+// WithMaxEvents limits how many events are returned for a pull request, keeping the
+// earliest. When a pull request has more, the kept events are returned with ErrMaxEvents.
+// Values below 1 mean no limit.
+func WithMaxEvents(n int) Option {
+	return func(c *Client) {
+		c.maxEvents = max(n, 0)
+	}
+}
+
+// WithDependencyBumpCompaction collapses the timeline of automated dependency
+// version bumps down to the opening, the final result of each check, and the merge
+// or close, and sets PullRequest.DependencyBump. A pull request qualifies when its
+// author is one of bots and its title reads like a version bump ("Bump x from 1.2
+// to 1.3"). With no bots given, Dependabot and Renovate are recognized.
+// Summaries such as TestSummary are still computed from the full timeline.
+func WithDependencyBumpCompaction(bots ...string) Option {
+	return func(c *Client) {
+		if len(bots) == 0 {
+			bots = defaultDependencyBots
+		}
+		c.dependencyBots = bots
+	}
+}
+
+// WithLinkedIssues merges the comments and timeline events of issues the pull
+// request closes ("Fixes #123" in its description) into its events, with
+// Event.Source set to the issue number. At most limit issues are fetched; values
+// below 1 use a default of 3. Linked issues cost extra API calls and are not cached.
+func WithLinkedIssues(limit int) Option {
+	return func(c *Client) {
+		if limit < 1 {
+			limit = defaultLinkedIssueLimit
+		}
+		c.linkedIssueLimit = limit
+	}
+}
+
+// WithReactions records emoji reaction counts on comment and review comment events.
+// GitHub includes them with each comment, so this costs no extra requests. The REST
+// API does not report reactions on review summaries, so review events have none.
+func WithReactions() Option {
+	return func(c *Client) {
+		c.reactions = true
+	}
+}
+
+// WithBodyLimit sets how many bytes of comment, review, commit message, and pull
+// request bodies are kept; longer text is cut on a rune boundary and ends with "…".
+// The default is 256. A limit of 0 keeps bodies whole; negative values are ignored.
+func WithBodyLimit(n int) Option {
+	return func(c *Client) {
+		if n >= 0 {
+			c.bodyLimit = n
+		}
+	}
+}
+
+// WithPermissionCacheTTL sets how long a user's resolved repository permission is
+// reused before being fetched again. Each user is otherwise looked up once per
+// repository and cached for 24 hours. Values of 0 or less are ignored.
+func WithPermissionCacheTTL(d time.Duration) Option {
+	return func(c *Client) {
+		if d > 0 {
+			c.permissionTTL = d
+		}
+	}
+}
+
+// WithWriteAccessLookup controls whether the permission API is called to confirm the
+// write access of organization members and commit authors; it is on by default.
+// When off, no permission requests are made: members and commit authors are
+// reported as WriteAccessUnknown, and everyone else as their author association implies.
+func WithWriteAccessLookup(enabled bool) Option {
+	return func(c *Client) {
+		c.noWriteAccessLookup = !enabled
+	}
+}
+
+// WithUserAgent sets the User-Agent sent to GitHub so API logs identify the
+// calling application. The default is "prx/<version>".
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithRetryAttempts sets the maximum number of attempts, including the first,
+// for requests that fail with 429, 5xx, or a secondary rate limit. Values below 1 are ignored.
+func WithRetryAttempts(n int) Option {
+	return func(c *Client) {
+		if n > 0 {
+			c.retryAttempts = n
+		}
+	}
+}
+
+// WithRetryDelay sets the initial delay between retries, which doubles with each
+// attempt and has jitter added. A Retry-After from GitHub takes precedence.
+// Values of 0 or less are ignored.
+func WithRetryDelay(d time.Duration) Option {
+	return func(c *Client) {
+		if d > 0 {
+			c.retryDelay = d
+		}
+	}
+}
+
 // NewClient creates a new Client with the given GitHub token.
-// If token is empty, WithHTTPClient option must be provided.
+// An empty token makes anonymous requests, which see only public data
+// and share GitHub's much lower unauthenticated rate limit.
 func NewClient(token string, opts ...Option) *Client {
 	transport := &http.Transport{
 		MaxIdleConns:        100,
@@ -72,11 +411,15 @@ func NewClient(token string, opts ...Option) *Client {
 	}
 
 	c := &Client{
-		logger: slog.Default(),
-		token:  token,
+		logger:           discardLogger,
+		token:            token,
+		fetchConcurrency: defaultFetchConcurrency,
+		bodyLimit:        defaultBodyLimit,
+		maxPages:         defaultMaxPages,
+		permissionSem:    make(chan struct{}, defaultPermissionConcurrency),
 		github: newGithubClient(&http.Client{
 			Transport: &RetryTransport{Base: transport},
-			Timeout:   30 * time.Second,
+			Timeout:   defaultHTTPTimeout,
 		}, token),
 	}
 
@@ -89,10 +432,48 @@ func NewClient(token string, opts ...Option) *Client {
 	for _, opt := range opts {
 		opt(c)
 	}
+	c.permissionCache.ttl = c.permissionTTL
+
+	if gc, ok := c.github.(*githubClient); ok {
+		if c.etags != nil {
+			gc.etags = &etagState{cache: c.etags, logger: c.logger}
+		}
+		if c.baseURL != "" {
+			gc.api = c.baseURL
+		}
+		if c.userAgent != "" {
+			gc.userAgent = c.userAgent
+		}
+		if c.tokenSource != nil {
+			gc.tokens = c.tokenSource
+		}
+		gc.logger = c.logger
+		gc.tracer = c.tracer
+		if rt, ok := gc.client.Transport.(*RetryTransport); ok {
+			// A transport passed in with WithHTTPClient keeps its own settings unless overridden.
+			if c.logger != discardLogger || rt.Logger == nil {
+				rt.Logger = c.logger
+			}
+			if c.retryAttempts > 0 {
+				rt.Attempts = uint(c.retryAttempts)
+			}
+			if c.retryDelay > 0 {
+				rt.Delay = c.retryDelay
+			}
+		}
+	}
 
 	return c
 }
 
+// truncateBody shortens s to the client's body limit.
+func (c *Client) truncateBody(s string) string {
+	if c.bodyLimit <= 0 {
+		return s
+	}
+	return truncate(s, c.bodyLimit)
+}
+
 // writeAccess returns the write access level for a user.
 func (c *Client) writeAccess(ctx context.Context, owner, repo string, user *githubUser, association string) int {
 	if user == nil {
@@ -105,7 +486,12 @@ func (c *Client) writeAccess(ctx context.Context, owner, repo string, user *gith
 		return WriteAccessDefinitely
 	case "MEMBER":
 		// Need to check via API
-		perm, _ := c.userPermissionCached(ctx, owner, repo, user.Login, association)
+		perm, err := c.userPermissionCached(ctx, owner, repo, user.Login, association)
+		if err != nil {
+			// A failed or skipped lookup says nothing about the user, so report unknown rather than unlikely.
+			c.logger.DebugContext(ctx, "unable to resolve member permission", "user", user.Login, "error", err)
+			return WriteAccessUnknown
+		}
 		if perm == "uncertain" {
 			return WriteAccessLikely
 		}
@@ -120,6 +506,10 @@ func (c *Client) writeAccess(ctx context.Context, owner, repo string, user *gith
 	}
 }
 
+// errPermissionLookupSkipped is returned by userPermissionCached when lookups are
+// turned off or the token's scopes do not allow them, so nothing is known.
+var errPermissionLookupSkipped = errors.New("permission lookup skipped")
+
 // userPermissionCached checks user permissions with caching.
 func (c *Client) userPermissionCached(ctx context.Context, owner, repo, username, authorAssociation string) (string, error) {
 	// Check cache first
@@ -128,6 +518,18 @@ func (c *Client) userPermissionCached(ctx context.Context, owner, repo, username
 		return perm, nil
 	}
 
+	if c.noWriteAccessLookup {
+		return "", errPermissionLookupSkipped
+	}
+
+	if caps := c.capabilities.Load(); caps != nil && !caps.PermissionResolution {
+		c.logger.DebugContext(ctx, "skipping permission check: unavailable due to token scope",
+			"owner", owner,
+			"repo", repo,
+			"user", username)
+		return "", errPermissionLookupSkipped
+	}
+
 	// Not in cache, fetch from API
 	c.logger.InfoContext(ctx, "permission cache miss - checking user permissions via API",
 		"owner", owner,
@@ -136,6 +538,21 @@ func (c *Client) userPermissionCached(ctx context.Context, owner, repo, username
 		"author_association", authorAssociation,
 		"reason", "not in cache")
 
+	if c.collaboratorListing {
+		if perm, ok := c.collaboratorPermission(ctx, owner, repo, username); ok {
+			return perm, nil
+		}
+	}
+
+	if c.permissionSem != nil {
+		select {
+		case c.permissionSem <- struct{}{}:
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+		defer func() { <-c.permissionSem }()
+	}
+
 	perm, err := c.github.userPermission(ctx, owner, repo, username)
 	if err != nil {
 		// Check if this is a 403 error (no permission to check)
@@ -166,49 +583,84 @@ func (c *Client) userPermissionCached(ctx context.Context, owner, repo, username
 	return perm, nil
 }
 
-// PullRequest fetches a pull request with all its events and metadata.
-func (c *Client) PullRequest(ctx context.Context, owner, repo string, prNumber int) (*PullRequestData, error) {
-	c.logger.InfoContext(ctx, "fetching pull request",
-		"owner", owner,
-		"repo", repo,
-		"pr", prNumber,
-	)
+// source is one independently fetched list of pull request events.
+type source struct {
+	name  string
+	fetch func() ([]Event, error)
+}
 
-	var events []Event
+// fetchSources runs the given fetches concurrently, at most c.fetchConcurrency at a time.
+// Failed sources are logged and reported in errs; events from the others, and any
+// partial events from truncated sources, are still returned. One source failing
+// deliberately does not cancel the rest, so callers get as much of the timeline as possible.
+// When ctx carries an event sink the events have already been streamed and none are returned.
+func (c *Client) fetchSources(ctx context.Context, sources []source) (events []Event, errs []error) {
+	limit := c.fetchConcurrency
+	if limit <= 0 || limit > len(sources) {
+		limit = len(sources)
+	}
+	sem := make(chan struct{}, limit)
 
-	// Fetch the pull request to get basic info
-	var pr githubPullRequest
-	path := fmt.Sprintf("/repos/%s/%s/pulls/%d", owner, repo, prNumber)
-	if _, err := c.github.get(ctx, path, &pr); err != nil {
-		c.logger.ErrorContext(ctx, "failed to fetch pull request", "error", err)
-		return nil, fmt.Errorf("fetching pull request: %w", err)
+	type result struct {
+		events []Event
+		err    error
+		name   string
+	}
+	results := make(chan result, len(sources))
+	for _, s := range sources {
+		go func() {
+			// Sources still queued when the caller gives up are skipped rather than started.
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				results <- result{nil, fmt.Errorf("fetching %s: %w", s.name, ctx.Err()), s.name}
+				return
+			}
+			defer func() { <-sem }()
+			e, err := s.fetch()
+			results <- result{e, err, s.name}
+		}()
 	}
 
-	c.logger.InfoContext(ctx, "pull request metadata",
-		"mergeable", pr.Mergeable,
-		"mergeable_state", pr.MergeableState,
-		"draft", pr.Draft,
-		"additions", pr.Additions,
-		"deletions", pr.Deletions,
-		"changed_files", pr.ChangedFiles,
-		"pr", prNumber)
+	for range sources {
+		r := <-results
+		if r.err != nil {
+			c.logger.ErrorContext(ctx, "failed to fetch "+r.name, "error", r.err)
+			errs = append(errs, r.err)
+		}
+		// Truncated sources still carry the events fetched before the limit.
+		if !streaming(ctx) {
+			events = append(events, r.events...)
+		}
+	}
+	return events, errs
+}
 
+// pullRequestMetadata converts a GitHub pull request into the exported PullRequest.
+func (c *Client) pullRequestMetadata(ctx context.Context, owner, repo string, pr *githubPullRequest) PullRequest {
 	pullRequest := PullRequest{
 		Number:         pr.Number,
 		Title:          pr.Title,
-		Body:           truncate(pr.Body, 256),
+		Body:           c.truncateBody(pr.Body),
 		State:          pr.State,
 		Draft:          pr.Draft,
 		Merged:         pr.Merged,
 		Mergeable:      pr.Mergeable,
 		MergeableState: pr.MergeableState,
-		CreatedAt:      pr.CreatedAt,
-		UpdatedAt:      pr.UpdatedAt,
+		CreatedAt:      pr.CreatedAt.Time,
+		UpdatedAt:      pr.UpdatedAt.Time,
 		Author:         pr.User.Login,
-		AuthorBot:      isBot(pr.User),
+		AuthorBot:      c.isBot(pr.User),
 		Additions:      pr.Additions,
 		Deletions:      pr.Deletions,
 		ChangedFiles:   pr.ChangedFiles,
+		HeadSHA:        pr.Head.SHA,
+		BaseSHA:        pr.Base.SHA,
+	}
+
+	if pr.Head.Repo != nil {
+		pullRequest.HeadRepo = pr.Head.Repo.FullName
+		pullRequest.IsFork = pr.fromFork()
 	}
 
 	// Check if PR author has write access
@@ -216,11 +668,11 @@ func (c *Client) PullRequest(ctx context.Context, owner, repo string, prNumber i
 		pullRequest.AuthorWriteAccess = c.writeAccess(ctx, owner, repo, pr.User, pr.AuthorAssociation)
 	}
 
-	if !pr.ClosedAt.IsZero() {
-		pullRequest.ClosedAt = &pr.ClosedAt
+	if !pr.ClosedAt.Time.IsZero() {
+		pullRequest.ClosedAt = &pr.ClosedAt.Time
 	}
-	if !pr.MergedAt.IsZero() {
-		pullRequest.MergedAt = &pr.MergedAt
+	if !pr.MergedAt.Time.IsZero() {
+		pullRequest.MergedAt = &pr.MergedAt.Time
 	}
 	if pr.MergedBy != nil {
 		pullRequest.MergedBy = pr.MergedBy.Login
@@ -238,120 +690,92 @@ func (c *Client) PullRequest(ctx context.Context, owner, repo string, prNumber i
 		}
 	}
 
+	for _, team := range pr.RequestedTeams {
+		if team != nil && team.Name != "" {
+			pullRequest.RequestedTeams = append(pullRequest.RequestedTeams, team.Name)
+		}
+	}
+
 	for _, label := range pr.Labels {
 		if label.Name != "" {
 			pullRequest.Labels = append(pullRequest.Labels, label.Name)
 		}
 	}
 
-	prOpenedEvent := Event{
-		Kind:        "pr_opened",
-		Timestamp:   pr.CreatedAt,
-		Actor:       pr.User.Login,
-		Bot:         isBot(pr.User),
-		WriteAccess: c.writeAccess(ctx, owner, repo, pr.User, pr.AuthorAssociation),
-	}
-	events = append(events, prOpenedEvent)
+	return pullRequest
+}
 
-	// Fetch all event types in parallel
-	type result struct {
-		events []Event
-		err    error
-		name   string
+// limitEvents applies the WithMaxEvents cap to sorted events. It returns the
+// error to report with the results: ErrMaxEvents if events were dropped,
+// otherwise the first truncation among the fetch errors, if any.
+func (c *Client) limitEvents(ctx context.Context, events []Event, errs []error) ([]Event, error) {
+	if c.maxEvents > 0 && len(events) > c.maxEvents {
+		c.logger.WarnContext(ctx, "event limit reached, dropping later events",
+			"max_events", c.maxEvents,
+			"event_count", len(events))
+		return events[:c.maxEvents], fmt.Errorf("%w: kept %d of %d events", ErrMaxEvents, c.maxEvents, len(events))
 	}
+	return events, firstTruncation(errs)
+}
 
-	results := make(chan result, 7)
-
-	go func() {
-		e, err := c.commits(ctx, owner, repo, prNumber)
-		results <- result{e, err, "commits"}
-	}()
-
-	go func() {
-		e, err := c.comments(ctx, owner, repo, prNumber)
-		results <- result{e, err, "comments"}
-	}()
-
-	go func() {
-		e, err := c.reviews(ctx, owner, repo, prNumber)
-		results <- result{e, err, "reviews"}
-	}()
-
-	go func() {
-		e, err := c.reviewComments(ctx, owner, repo, prNumber)
-		results <- result{e, err, "review comments"}
-	}()
-
-	go func() {
-		e, err := c.timelineEvents(ctx, owner, repo, prNumber)
-		results <- result{e, err, "timeline events"}
-	}()
-
-	go func() {
-		e, err := c.statusChecks(ctx, owner, repo, &pr)
-		results <- result{e, err, "status checks"}
-	}()
-
-	go func() {
-		e, err := c.checkRuns(ctx, owner, repo, &pr)
-		results <- result{e, err, "check runs"}
-	}()
+// PullRequest fetches a pull request with all its events and metadata.
+//
+// If a limit such as WithMaxPages or WithMaxEvents cuts the results short, the
+// partial data is returned together with an error wrapping ErrTruncated.
+func (c *Client) PullRequest(ctx context.Context, owner, repo string, prNumber int) (*PullRequestData, error) {
+	c.logger.InfoContext(ctx, "fetching pull request",
+		"owner", owner,
+		"repo", repo,
+		"pr", prNumber,
+	)
 
-	// Collect results
-	var errors []error
-	for i := 0; i < 7; i++ {
-		r := <-results
-		if r.err != nil {
-			c.logger.ErrorContext(ctx, "failed to fetch "+r.name, "error", r.err)
-			errors = append(errors, r.err)
-		} else {
-			events = append(events, r.events...)
-		}
+	var pr *githubPullRequest
+	var sources []source
+	var err error
+	if c.graphQL {
+		pr, sources, err = c.graphQLPullRequest(ctx, owner, repo, prNumber)
+	} else if pr, err = c.pullRequest(ctx, owner, repo, prNumber); err == nil {
+		sources = c.eventSources(ctx, owner, repo, pr)
+	}
+	if err != nil {
+		return nil, err
 	}
+	pullRequest := c.pullRequestMetadata(ctx, owner, repo, pr)
+
+	events := []Event{c.openedEvent(ctx, owner, repo, pr)}
+	fetched, errs := c.fetchSources(ctx, sources)
+	events = append(events, fetched...)
 
 	// If we have no events at all and errors occurred, return the first error
-	if len(events) == 0 && len(errors) > 0 {
-		return nil, fmt.Errorf("failed to fetch any events: %w", errors[0])
+	if len(events) == 0 && len(errs) > 0 {
+		return nil, fmt.Errorf("failed to fetch any events: %w", errs[0])
 	}
 
 	// Log a warning if we had partial failures
-	if len(errors) > 0 {
+	if len(errs) > 0 {
 		c.logger.WarnContext(ctx, "some event fetches failed but returning partial data",
-			"error_count", len(errors),
+			"error_count", len(errs),
 			"event_count", len(events))
 	}
 
-	if pr.Merged {
-		mergedEvent := Event{
-			Kind:      "pr_merged",
-			Timestamp: pr.MergedAt,
-		}
-		if pr.MergedBy != nil {
-			mergedEvent.Actor = pr.MergedBy.Login
-			mergedEvent.Bot = isBot(pr.MergedBy)
-		} else {
-			mergedEvent.Actor = "unknown"
-		}
-		events = append(events, mergedEvent)
-	} else if pr.State == "closed" {
-		closedEvent := Event{
-			Kind:        "pr_closed",
-			Timestamp:   pr.ClosedAt,
-			Actor:       pr.User.Login,
-			Bot:         isBot(pr.User),
-			WriteAccess: c.writeAccess(ctx, owner, repo, pr.User, pr.AuthorAssociation),
-		}
-		events = append(events, closedEvent)
+	if closing := c.closingEvent(ctx, owner, repo, pr); closing != nil {
+		events = append(events, *closing)
 	}
 
 	// Filter events to exclude non-failure status_check events
 	events = filterEvents(events)
 
-	sortEventsByTimestamp(events)
+	SortEvents(events)
+	events, truncated := c.limitEvents(ctx, events, errs)
+	if c.groupReviewComments {
+		events = groupByReview(events)
+	}
 
 	// Upgrade write_access from likely (1) to definitely (2) for actors who performed write-access-requiring actions
 	upgradeWriteAccess(events)
 
+	stampTraceID(ctx, events)
+
 	testSummary := calculateTestSummary(events)
 	if testSummary.Passing > 0 || testSummary.Failing > 0 || testSummary.Pending > 0 {
 		pullRequest.TestSummary = testSummary
@@ -367,6 +791,8 @@ func (c *Client) PullRequest(ctx context.Context, owner, repo string, prNumber i
 		pullRequest.ApprovalSummary = approvalSummary
 	}
 
+	events = c.compactIfDependencyBump(ctx, &pullRequest, events)
+
 	c.logger.InfoContext(ctx, "successfully fetched pull request",
 		"owner", owner,
 		"repo", repo,
@@ -377,5 +803,118 @@ func (c *Client) PullRequest(ctx context.Context, owner, repo string, prNumber i
 	return &PullRequestData{
 		PullRequest: pullRequest,
 		Events:      events,
-	}, nil
+	}, truncated
+}
+
+// pullRequest fetches a pull request's details, checking GitHub answered for the right one.
+func (c *Client) pullRequest(ctx context.Context, owner, repo string, prNumber int) (*githubPullRequest, error) {
+	var pr githubPullRequest
+	path := fmt.Sprintf("/repos/%s/%s/pulls/%d", owner, repo, prNumber)
+	if _, err := c.github.get(ctx, path, &pr); err != nil {
+		c.logger.ErrorContext(ctx, "failed to fetch pull request", "error", err)
+		return nil, fmt.Errorf("fetching pull request: %w", err)
+	}
+	if err := checkPullRequestNumber(&pr, prNumber); err != nil {
+		c.logger.ErrorContext(ctx, "pull request response does not match request", "error", err)
+		return nil, err
+	}
+
+	c.logger.InfoContext(ctx, "pull request metadata",
+		"mergeable", pr.Mergeable,
+		"mergeable_state", pr.MergeableState,
+		"draft", pr.Draft,
+		"additions", pr.Additions,
+		"deletions", pr.Deletions,
+		"changed_files", pr.ChangedFiles,
+		"pr", prNumber)
+	return &pr, nil
+}
+
+// eventSources lists the fetches that make up a pull request's timeline.
+func (c *Client) eventSources(ctx context.Context, owner, repo string, pr *githubPullRequest) []source {
+	prNumber := pr.Number
+	return append([]source{
+		{"commits", func() ([]Event, error) { return c.commits(ctx, owner, repo, prNumber) }},
+		{"comments", func() ([]Event, error) { return c.comments(ctx, owner, repo, prNumber) }},
+		{"reviews", func() ([]Event, error) { return c.reviews(ctx, owner, repo, prNumber) }},
+		{"review comments", func() ([]Event, error) { return c.reviewComments(ctx, owner, repo, prNumber) }},
+		{"timeline events", func() ([]Event, error) { return c.timelineEvents(ctx, owner, repo, prNumber) }},
+		{"status checks", func() ([]Event, error) { return c.statusChecks(ctx, owner, repo, pr) }},
+		{"check runs", func() ([]Event, error) { return c.checkRuns(ctx, owner, repo, pr) }},
+	}, c.linkedIssueSources(ctx, owner, repo, prNumber, pr.Body)...)
+}
+
+// openedEvent returns the pr_opened event that starts every timeline, carrying the
+// description as its body. Question is left unset: PR templates are full of questions
+// the author answers rather than asks.
+func (c *Client) openedEvent(ctx context.Context, owner, repo string, pr *githubPullRequest) Event {
+	return Event{
+		Kind:        "pr_opened",
+		Timestamp:   pr.CreatedAt.Time,
+		Actor:       pr.User.Login,
+		Bot:         c.isBot(pr.User),
+		Body:        c.truncateBody(pr.Body),
+		WriteAccess: c.writeAccess(ctx, owner, repo, pr.User, pr.AuthorAssociation),
+	}
+}
+
+// closingEvent returns the pr_merged or pr_closed event ending the timeline, or nil while the PR is open.
+func (c *Client) closingEvent(ctx context.Context, owner, repo string, pr *githubPullRequest) *Event {
+	if pr.Merged {
+		mergedEvent := Event{
+			Kind:      "pr_merged",
+			Timestamp: pr.MergedAt.Time,
+		}
+		if pr.MergedBy != nil {
+			mergedEvent.Actor = pr.MergedBy.Login
+			mergedEvent.Bot = c.isBot(pr.MergedBy)
+		} else {
+			mergedEvent.Actor = "unknown"
+		}
+		return &mergedEvent
+	}
+	if pr.State == "closed" {
+		return &Event{
+			Kind:        "pr_closed",
+			Timestamp:   pr.ClosedAt.Time,
+			Actor:       pr.User.Login,
+			Bot:         c.isBot(pr.User),
+			WriteAccess: c.writeAccess(ctx, owner, repo, pr.User, pr.AuthorAssociation),
+		}
+	}
+	return nil
+}
+
+// PullRequestEvents returns the merged, chronologically sorted timeline of a pull request:
+// commits, comments, reviews, review comments, timeline events, status checks, and check runs.
+// It is PullRequest without the metadata; partial events are returned alongside an error
+// wrapping ErrTruncated when a limit cuts the results short.
+func (c *Client) PullRequestEvents(ctx context.Context, owner, repo string, prNumber int) ([]Event, error) {
+	data, err := c.PullRequest(ctx, owner, repo, prNumber)
+	if data == nil {
+		return nil, err
+	}
+	return data.Events, err
+}
+
+// PullRequestSummary fetches a pull request's headline metadata (title, state, size, labels,
+// assignees, and so on) without its events. The summaries computed from events are left nil.
+func (c *Client) PullRequestSummary(ctx context.Context, owner, repo string, prNumber int) (*PullRequest, error) {
+	pr, err := c.pullRequest(ctx, owner, repo, prNumber)
+	if err != nil {
+		return nil, err
+	}
+	summary := c.pullRequestMetadata(ctx, owner, repo, pr)
+	return &summary, nil
+}
+
+// PullRequestUpdatedAt returns when a pull request last changed, for callers that store it
+// and skip a full PullRequest fetch when it has not moved. It costs a single API request,
+// or a free conditional one with WithETagCache.
+func (c *Client) PullRequestUpdatedAt(ctx context.Context, owner, repo string, prNumber int) (time.Time, error) {
+	pr, err := c.pullRequest(ctx, owner, repo, prNumber)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return pr.UpdatedAt.Time, nil
 }
@@ -1,10 +1,19 @@
 package prx
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -50,6 +59,21 @@ func (m *mockGithubClient) raw(ctx context.Context, path string) (json.RawMessag
 	return json.RawMessage("[]"), &githubResponse{NextPage: 0}, nil
 }
 
+func (m *mockGithubClient) graphql(ctx context.Context, query string, variables map[string]any, v any) error {
+	m.mu.Lock()
+	m.calls = append(m.calls, "graphql")
+	m.mu.Unlock()
+
+	if response, ok := m.responses["graphql"]; ok {
+		data, err := json.Marshal(response)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(data, v)
+	}
+	return nil
+}
+
 func (m *mockGithubClient) userPermission(ctx context.Context, owner, repo, username string) (string, error) {
 	path := "/repos/" + owner + "/" + repo + "/collaborators/" + username + "/permission"
 	m.calls = append(m.calls, path)
@@ -71,26 +95,21 @@ func TestClientWithMock(t *testing.T) {
 				Number:            1,
 				Title:             "Test PR",
 				Body:              "Test description",
-				CreatedAt:         time.Now().Add(-24 * time.Hour),
-				UpdatedAt:         time.Now().Add(-1 * time.Hour),
+				CreatedAt:         githubTime{Time: time.Now().Add(-24 * time.Hour)},
+				UpdatedAt:         githubTime{Time: time.Now().Add(-1 * time.Hour)},
 				User:              &githubUser{Login: "testuser"},
 				AuthorAssociation: "CONTRIBUTOR",
 				State:             "open",
-				Head: struct {
-					SHA string `json:"sha"`
-					Ref string `json:"ref"`
-				}{SHA: "abc123", Ref: "feature-branch"},
-				Base: struct {
-					Ref string `json:"ref"`
-				}{Ref: "main"},
+				Head:              githubRef{SHA: "abc123", Ref: "feature-branch"},
+				Base:              githubRef{SHA: "def456", Ref: "main"},
 			},
-			"/repos/owner/repo/pulls/1/commits?page=1&per_page=100":    []*githubPullRequestCommit{},
-			"/repos/owner/repo/issues/1/comments?page=1&per_page=100":  []*githubComment{},
-			"/repos/owner/repo/pulls/1/reviews?page=1&per_page=100":    []*githubReview{},
-			"/repos/owner/repo/pulls/1/comments?page=1&per_page=100":   []*githubReviewComment{},
-			"/repos/owner/repo/issues/1/timeline?page=1&per_page=100":  []*githubTimelineEvent{},
-			"/repos/owner/repo/statuses/abc123?per_page=100":           []*githubStatus{},
-			"/repos/owner/repo/commits/abc123/check-runs?per_page=100": githubCheckRuns{CheckRuns: []*githubCheckRun{}},
+			"/repos/owner/repo/pulls/1/commits?page=1&per_page=100":           []*githubPullRequestCommit{},
+			"/repos/owner/repo/issues/1/comments?page=1&per_page=100":         []*githubComment{},
+			"/repos/owner/repo/pulls/1/reviews?page=1&per_page=100":           []*githubReview{},
+			"/repos/owner/repo/pulls/1/comments?page=1&per_page=100":          []*githubReviewComment{},
+			"/repos/owner/repo/issues/1/timeline?page=1&per_page=100":         []*githubTimelineEvent{},
+			"/repos/owner/repo/statuses/abc123?page=1&per_page=100":           []*githubStatus{},
+			"/repos/owner/repo/commits/abc123/check-runs?page=1&per_page=100": githubCheckRuns{CheckRuns: []*githubCheckRun{}},
 		},
 	}
 
@@ -112,6 +131,9 @@ func TestClientWithMock(t *testing.T) {
 	if data.PullRequest.Number != 1 {
 		t.Errorf("Expected PR number 1, got %d", data.PullRequest.Number)
 	}
+	if data.PullRequest.HeadSHA != "abc123" || data.PullRequest.BaseSHA != "def456" {
+		t.Errorf("Expected head/base SHAs abc123/def456, got %s/%s", data.PullRequest.HeadSHA, data.PullRequest.BaseSHA)
+	}
 
 	// Should have at least the PR opened event
 	if len(data.Events) < 1 {
@@ -126,8 +148,8 @@ func TestClientWithMock(t *testing.T) {
 		"/repos/owner/repo/pulls/1/reviews?page=1&per_page=100",
 		"/repos/owner/repo/pulls/1/comments?page=1&per_page=100",
 		"/repos/owner/repo/issues/1/timeline?page=1&per_page=100",
-		"/repos/owner/repo/statuses/abc123?per_page=100",
-		"/repos/owner/repo/commits/abc123/check-runs?per_page=100",
+		"/repos/owner/repo/statuses/abc123?page=1&per_page=100",
+		"/repos/owner/repo/commits/abc123/check-runs?page=1&per_page=100",
 	}
 
 	mock.mu.Lock()
@@ -141,3 +163,639 @@ func TestClientWithMock(t *testing.T) {
 		t.Logf("Actual calls: %v", callsCopy)
 	}
 }
+
+// slowPermissionClient records how many permission lookups run at once.
+type slowPermissionClient struct {
+	mockGithubClient
+	inFlight    atomic.Int32
+	maxInFlight atomic.Int32
+}
+
+func (m *slowPermissionClient) userPermission(ctx context.Context, owner, repo, username string) (string, error) {
+	n := m.inFlight.Add(1)
+	defer m.inFlight.Add(-1)
+	for {
+		peak := m.maxInFlight.Load()
+		if n <= peak || m.maxInFlight.CompareAndSwap(peak, n) {
+			break
+		}
+	}
+	time.Sleep(10 * time.Millisecond)
+	return "write", nil
+}
+
+func TestPermissionConcurrencyLimit(t *testing.T) {
+	mock := &slowPermissionClient{}
+	client := NewClient("token", WithFetchConcurrency(7), WithPermissionConcurrency(3))
+	client.github = mock
+
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			user := &githubUser{Login: fmt.Sprintf("member%d", i)}
+			if got := client.writeAccess(context.Background(), "owner", "repo", user, "MEMBER"); got != WriteAccessDefinitely {
+				t.Errorf("writeAccess() = %d, want %d", got, WriteAccessDefinitely)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if peak := mock.maxInFlight.Load(); peak > 3 {
+		t.Errorf("permission lookups peaked at %d concurrent calls, limit is 3", peak)
+	}
+	if client.fetchConcurrency != 7 {
+		t.Errorf("fetchConcurrency = %d, want 7", client.fetchConcurrency)
+	}
+}
+
+func TestPullRequestNumberMismatch(t *testing.T) {
+	mock := &mockGithubClient{
+		responses: map[string]any{
+			"/repos/owner/repo/pulls/1": githubPullRequest{
+				Number: 2,
+				State:  "open",
+				User:   &githubUser{Login: "author"},
+			},
+		},
+	}
+	client := &Client{
+		github:          mock,
+		logger:          slog.Default(),
+		permissionCache: &permissionCache{memory: make(map[string]permissionEntry)},
+	}
+
+	data, err := client.PullRequest(context.Background(), "owner", "repo", 1)
+	if !errors.Is(err, ErrUnexpectedResponse) {
+		t.Fatalf("PullRequest() error = %v, want ErrUnexpectedResponse", err)
+	}
+	if data != nil {
+		t.Errorf("expected no data on mismatch, got %+v", data.PullRequest)
+	}
+	if len(mock.calls) != 1 {
+		t.Errorf("expected fetching to stop after the metadata call, got calls %v", mock.calls)
+	}
+}
+
+func TestReviewCommentGrouping(t *testing.T) {
+	base := time.Now().Add(-time.Hour)
+	at := func(ms int) githubTime { return githubTime{Time: base.Add(time.Duration(ms) * time.Millisecond)} }
+	mock := &mockGithubClient{
+		responses: map[string]any{
+			"/repos/owner/repo/pulls/1": githubPullRequest{
+				Number:    1,
+				State:     "open",
+				CreatedAt: at(0),
+				User:      &githubUser{Login: "author"},
+			},
+			"/repos/owner/repo/pulls/1/reviews?page=1&per_page=100": []*githubReview{
+				{ID: 5, User: &githubUser{Login: "reviewer"}, State: "COMMENTED", SubmittedAt: at(10000)},
+			},
+			"/repos/owner/repo/pulls/1/comments?page=1&per_page=100": []*githubReviewComment{
+				{User: &githubUser{Login: "reviewer"}, Body: "early", CreatedAt: at(9500), PullRequestReviewID: 5},
+				{User: &githubUser{Login: "reviewer"}, Body: "late", CreatedAt: at(10500), PullRequestReviewID: 5},
+			},
+			"/repos/owner/repo/issues/1/comments?page=1&per_page=100": []*githubComment{
+				{User: &githubUser{Login: "author"}, Body: "thanks", CreatedAt: at(10200)},
+			},
+		},
+	}
+
+	tests := []struct {
+		name string
+		opts []Option
+		want []string
+	}{
+		{
+			name: "chronological",
+			want: []string{"pr_opened", "review_comment", "review", "comment", "review_comment"},
+		},
+		{
+			name: "grouped",
+			opts: []Option{WithReviewCommentGrouping()},
+			want: []string{"pr_opened", "review", "review_comment", "review_comment", "comment"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &Client{
+				github:          mock,
+				logger:          slog.Default(),
+				permissionCache: &permissionCache{memory: make(map[string]permissionEntry)},
+			}
+			for _, opt := range tt.opts {
+				opt(client)
+			}
+
+			data, err := client.PullRequest(context.Background(), "owner", "repo", 1)
+			if err != nil {
+				t.Fatalf("PullRequest() error = %v", err)
+			}
+			var kinds []string
+			for _, e := range data.Events {
+				kinds = append(kinds, e.Kind)
+			}
+			if fmt.Sprint(kinds) != fmt.Sprint(tt.want) {
+				t.Errorf("event order = %v, want %v", kinds, tt.want)
+			}
+		})
+	}
+}
+
+func TestPullRequestMetadataRequestedTeams(t *testing.T) {
+	tests := []struct {
+		name          string
+		pr            githubPullRequest
+		wantReviewers []string
+		wantTeams     []string
+	}{
+		{
+			name: "individual and team",
+			pr: githubPullRequest{
+				RequestedReviewers: []*githubUser{{Login: "alice"}},
+				RequestedTeams:     []*githubTeam{{Name: "Platform", Slug: "platform"}},
+			},
+			wantReviewers: []string{"alice"},
+			wantTeams:     []string{"Platform"},
+		},
+		{
+			name: "none pending",
+			pr:   githubPullRequest{RequestedTeams: []*githubTeam{nil, {}}},
+		},
+	}
+
+	client := &Client{logger: slog.Default(), permissionCache: &permissionCache{memory: make(map[string]permissionEntry)}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.pr.User = &githubUser{Login: "author"}
+			got := client.pullRequestMetadata(context.Background(), "owner", "repo", &tt.pr)
+			if fmt.Sprint(got.RequestedReviewers) != fmt.Sprint(tt.wantReviewers) {
+				t.Errorf("RequestedReviewers = %v, want %v", got.RequestedReviewers, tt.wantReviewers)
+			}
+			if fmt.Sprint(got.RequestedTeams) != fmt.Sprint(tt.wantTeams) {
+				t.Errorf("RequestedTeams = %v, want %v", got.RequestedTeams, tt.wantTeams)
+			}
+		})
+	}
+}
+
+func TestPullRequestMetadataFork(t *testing.T) {
+	tests := []struct {
+		name         string
+		head         *githubRepo
+		base         *githubRepo
+		wantHeadRepo string
+		wantFork     bool
+	}{
+		{
+			name:         "fork",
+			head:         &githubRepo{FullName: "contributor/repo", Fork: true},
+			base:         &githubRepo{FullName: "owner/repo"},
+			wantHeadRepo: "contributor/repo",
+			wantFork:     true,
+		},
+		{
+			name:         "same repository",
+			head:         &githubRepo{FullName: "owner/repo"},
+			base:         &githubRepo{FullName: "owner/repo"},
+			wantHeadRepo: "owner/repo",
+		},
+		{
+			name: "deleted fork",
+			base: &githubRepo{FullName: "owner/repo"},
+		},
+	}
+
+	client := &Client{logger: slog.Default(), permissionCache: &permissionCache{memory: make(map[string]permissionEntry)}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pr := githubPullRequest{
+				User: &githubUser{Login: "contributor"},
+				Head: githubRef{SHA: "abc123", Repo: tt.head},
+				Base: githubRef{SHA: "def456", Repo: tt.base},
+			}
+			got := client.pullRequestMetadata(context.Background(), "owner", "repo", &pr)
+			if got.HeadRepo != tt.wantHeadRepo || got.IsFork != tt.wantFork {
+				t.Errorf("HeadRepo, IsFork = %q, %v, want %q, %v", got.HeadRepo, got.IsFork, tt.wantHeadRepo, tt.wantFork)
+			}
+		})
+	}
+}
+
+func TestPullRequestEvents(t *testing.T) {
+	base := time.Now().Add(-time.Hour)
+	at := func(minutes int) githubTime { return githubTime{Time: base.Add(time.Duration(minutes) * time.Minute)} }
+	commit := &githubPullRequestCommit{SHA: "abc123", Author: &githubUser{Login: "author"}}
+	commit.Commit.Message = "fix"
+	commit.Commit.Author.Date = at(1)
+	mock := &mockGithubClient{
+		responses: map[string]any{
+			"/repos/owner/repo/pulls/1": githubPullRequest{
+				Number:    1,
+				State:     "open",
+				Body:      "Fixes the flaky build. Did I miss anything?",
+				CreatedAt: at(0),
+				User:      &githubUser{Login: "author"},
+				Head:      githubRef{SHA: "abc123"},
+			},
+			"/repos/owner/repo/pulls/1/commits?page=1&per_page=100": []*githubPullRequestCommit{commit},
+			"/repos/owner/repo/issues/1/comments?page=1&per_page=100": []*githubComment{
+				{User: &githubUser{Login: "reviewer"}, Body: "looks good", CreatedAt: at(3)},
+			},
+			"/repos/owner/repo/pulls/1/reviews?page=1&per_page=100": []*githubReview{
+				{User: &githubUser{Login: "reviewer"}, State: "APPROVED", SubmittedAt: at(2)},
+			},
+			"/repos/owner/repo/commits/abc123/check-runs?page=1&per_page=100": githubCheckRuns{CheckRuns: []*githubCheckRun{
+				{Name: "build", Conclusion: "success", CompletedAt: at(4)},
+			}},
+		},
+	}
+	client := &Client{
+		github:          mock,
+		logger:          slog.Default(),
+		permissionCache: &permissionCache{memory: make(map[string]permissionEntry)},
+	}
+
+	events, err := client.PullRequestEvents(context.Background(), "owner", "repo", 1)
+	if err != nil {
+		t.Fatalf("PullRequestEvents() error = %v", err)
+	}
+	var kinds []string
+	for _, e := range events {
+		kinds = append(kinds, e.Kind)
+	}
+	want := []string{"pr_opened", "commit", "review", "comment", "check_run"}
+	if fmt.Sprint(kinds) != fmt.Sprint(want) {
+		t.Errorf("events = %v, want %v", kinds, want)
+	}
+	if opened := events[0]; opened.Body != "Fixes the flaky build. Did I miss anything?" || opened.Actor != "author" {
+		t.Errorf("pr_opened = %+v, want the description by author", opened)
+	}
+}
+
+func TestFetchSources(t *testing.T) {
+	client := &Client{logger: slog.Default(), fetchConcurrency: 2}
+
+	var mu sync.Mutex
+	var running, peak int
+	var sources []source
+	for i := range 6 {
+		sources = append(sources, source{fmt.Sprint("source ", i), func() ([]Event, error) {
+			mu.Lock()
+			running++
+			peak = max(peak, running)
+			mu.Unlock()
+			time.Sleep(10 * time.Millisecond)
+			mu.Lock()
+			running--
+			mu.Unlock()
+			if i == 3 {
+				return nil, errors.New("boom")
+			}
+			return []Event{{Kind: "comment"}}, nil
+		}})
+	}
+
+	events, errs := client.fetchSources(context.Background(), sources)
+	if len(events) != 5 || len(errs) != 1 {
+		t.Errorf("got %d events and %d errors, want 5 and 1", len(events), len(errs))
+	}
+	if peak > 2 {
+		t.Errorf("peak concurrency = %d, want at most 2", peak)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	client.fetchConcurrency = 1
+	_, errs = client.fetchSources(ctx, []source{
+		{"first", func() ([]Event, error) { return nil, ctx.Err() }},
+		{"second", func() ([]Event, error) { return nil, ctx.Err() }},
+	})
+	if len(errs) != 2 {
+		t.Errorf("got %d errors after cancel, want 2", len(errs))
+	}
+}
+
+func TestClientOptions(t *testing.T) {
+	var gotPath, gotAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotAgent = r.URL.Path, r.Header.Get("User-Agent")
+		if _, err := w.Write([]byte(`{"login":"octocat","type":"User"}`)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	httpClient := &http.Client{Transport: &http.Transport{}}
+	client := NewClient("test-token",
+		WithHTTPClient(httpClient),
+		WithLogger(logger),
+		WithBaseURL(server.URL+"/api/v3/"),
+		WithUserAgent("reviewbot/1.0"),
+	)
+
+	if client.logger != logger {
+		t.Error("WithLogger did not set the logger")
+	}
+	gc, ok := client.github.(*githubClient)
+	if !ok {
+		t.Fatalf("unexpected github client type %T", client.github)
+	}
+	if gc.client != httpClient {
+		t.Error("WithHTTPClient did not set the HTTP client")
+	}
+	if _, err := client.Verify(context.Background()); err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if gotPath != "/api/v3/user" {
+		t.Errorf("request path = %q, want /api/v3/user", gotPath)
+	}
+	if gotAgent != "reviewbot/1.0" {
+		t.Errorf("User-Agent = %q, want reviewbot/1.0", gotAgent)
+	}
+}
+
+// countingTransport counts requests before handing them to the default transport.
+type countingTransport struct {
+	requests atomic.Int32
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.requests.Add(1)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestWithHTTPClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if _, err := w.Write([]byte(`{"login":"octocat","type":"User"}`)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	transport := &countingTransport{}
+	client := NewClient("test-token", WithHTTPClient(&http.Client{Transport: transport}), WithBaseURL(server.URL))
+	if _, err := client.Verify(context.Background()); err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if got := transport.requests.Load(); got != 1 {
+		t.Errorf("custom transport saw %d requests, want 1", got)
+	}
+
+	gc, ok := NewClient("test-token", WithHTTPClient(nil)).github.(*githubClient)
+	if !ok {
+		t.Fatal("unexpected github client type")
+	}
+	if gc.client.Timeout != defaultHTTPTimeout {
+		t.Errorf("default client timeout = %v, want %v", gc.client.Timeout, defaultHTTPTimeout)
+	}
+}
+
+func TestWithLoggerCapturesRequestLogs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if _, err := w.Write([]byte(`{"login":"octocat","type":"User"}`)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	if _, err := newTestClient(t, server, WithLogger(logger)).Verify(context.Background()); err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	for _, want := range []string{"GitHub API request starting", "HTTP response received"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("log output missing %q:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestPullRequestUpdatedAt(t *testing.T) {
+	updated := time.Date(2024, 6, 1, 8, 0, 0, 0, time.UTC)
+	mock := &mockGithubClient{
+		responses: map[string]any{
+			"/repos/owner/repo/pulls/1": githubPullRequest{Number: 1, UpdatedAt: githubTime{Time: updated}},
+			"/repos/owner/repo/pulls/2": githubPullRequest{Number: 3},
+		},
+	}
+	client := &Client{github: mock, logger: slog.Default()}
+
+	got, err := client.PullRequestUpdatedAt(context.Background(), "owner", "repo", 1)
+	if err != nil {
+		t.Fatalf("PullRequestUpdatedAt() error = %v", err)
+	}
+	if !got.Equal(updated) {
+		t.Errorf("PullRequestUpdatedAt() = %v, want %v", got, updated)
+	}
+	if len(mock.calls) != 1 {
+		t.Errorf("expected a single API call, got %q", mock.calls)
+	}
+
+	if _, err := client.PullRequestUpdatedAt(context.Background(), "owner", "repo", 2); !errors.Is(err, ErrUnexpectedResponse) {
+		t.Errorf("PullRequestUpdatedAt() error = %v, want ErrUnexpectedResponse", err)
+	}
+}
+
+func TestPullRequestSummary(t *testing.T) {
+	created := time.Date(2024, 6, 1, 8, 0, 0, 0, time.UTC)
+	merged := created.Add(2 * time.Hour)
+	mock := &mockGithubClient{
+		responses: map[string]any{
+			"/repos/owner/repo/pulls/5": githubPullRequest{
+				Number:            5,
+				Title:             "Add retries",
+				Body:              "Retries transient failures.",
+				State:             "closed",
+				Merged:            true,
+				MergedBy:          &githubUser{Login: "maintainer"},
+				CreatedAt:         githubTime{Time: created},
+				UpdatedAt:         githubTime{Time: merged},
+				MergedAt:          githubTime{Time: merged},
+				ClosedAt:          githubTime{Time: merged},
+				User:              &githubUser{Login: "author"},
+				AuthorAssociation: "OWNER",
+				Head:              githubRef{SHA: "head1"},
+				Base:              githubRef{SHA: "base1"},
+				Additions:         12,
+				Deletions:         3,
+				ChangedFiles:      2,
+				Assignees:         []*githubUser{{Login: "author"}, nil},
+				Labels:            []githubLabel{{Name: "enhancement"}, {}},
+			},
+		},
+	}
+	client := &Client{github: mock, logger: slog.Default(), permissionCache: &permissionCache{memory: make(map[string]permissionEntry)}}
+
+	got, err := client.PullRequestSummary(context.Background(), "owner", "repo", 5)
+	if err != nil {
+		t.Fatalf("PullRequestSummary() error = %v", err)
+	}
+	want := &PullRequest{
+		Number:            5,
+		Title:             "Add retries",
+		Body:              "Retries transient failures.",
+		Author:            "author",
+		State:             "closed",
+		Merged:            true,
+		MergedBy:          "maintainer",
+		CreatedAt:         created,
+		UpdatedAt:         merged,
+		ClosedAt:          &merged,
+		MergedAt:          &merged,
+		HeadSHA:           "head1",
+		BaseSHA:           "base1",
+		Additions:         12,
+		Deletions:         3,
+		ChangedFiles:      2,
+		AuthorWriteAccess: WriteAccessDefinitely,
+		Assignees:         []string{"author"},
+		Labels:            []string{"enhancement"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("PullRequestSummary() = %+v, want %+v", got, want)
+	}
+	if len(mock.calls) != 1 {
+		t.Errorf("expected only the pull request to be fetched, got %q", mock.calls)
+	}
+}
+
+func TestWithWriteAccessLookupDisabled(t *testing.T) {
+	created := time.Date(2024, 6, 1, 8, 0, 0, 0, time.UTC)
+	mock := &mockGithubClient{
+		responses: map[string]any{
+			"/repos/owner/repo/pulls/1": githubPullRequest{
+				Number:            1,
+				State:             "open",
+				CreatedAt:         githubTime{Time: created},
+				User:              &githubUser{Login: "member"},
+				AuthorAssociation: "MEMBER",
+			},
+			"/repos/owner/repo/pulls/1/commits?page=1&per_page=100": []githubPullRequestCommit{
+				{SHA: "abc", Author: &githubUser{Login: "member"}},
+			},
+			"/repos/owner/repo/issues/1/comments?page=1&per_page=100": []githubComment{
+				{User: &githubUser{Login: "owner"}, CreatedAt: githubTime{Time: created.Add(time.Hour)}, AuthorAssociation: "OWNER"},
+				{User: &githubUser{Login: "drive-by"}, CreatedAt: githubTime{Time: created.Add(2 * time.Hour)}, AuthorAssociation: "NONE"},
+			},
+		},
+	}
+	client := NewClient("token", WithWriteAccessLookup(false))
+	client.github = mock
+
+	events, err := client.PullRequestEvents(context.Background(), "owner", "repo", 1)
+	if err != nil {
+		t.Fatalf("PullRequestEvents() error = %v", err)
+	}
+	for _, call := range mock.calls {
+		if strings.HasSuffix(call, "/permission") {
+			t.Errorf("permission lookup %q made with lookups disabled", call)
+		}
+	}
+
+	want := map[string]int{
+		"pr_opened/member": WriteAccessUnknown,
+		"commit/member":    WriteAccessUnknown,
+		"comment/owner":    WriteAccessDefinitely,
+		"comment/drive-by": WriteAccessUnlikely,
+	}
+	for _, e := range events {
+		key := e.Kind + "/" + e.Actor
+		if wantAccess, ok := want[key]; !ok {
+			t.Errorf("unexpected event %s", key)
+		} else if e.WriteAccess != wantAccess {
+			t.Errorf("%s: WriteAccess = %d, want %d", key, e.WriteAccess, wantAccess)
+		}
+	}
+}
+
+// permissionErrorClient fails every permission lookup with err.
+type permissionErrorClient struct {
+	*mockGithubClient
+	err error
+}
+
+func (p *permissionErrorClient) userPermission(context.Context, string, string, string) (string, error) {
+	return "", p.err
+}
+
+func TestWriteAccessLookupErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantMember int
+		wantCommit int
+	}{
+		{
+			name:       "forbidden",
+			err:        &GitHubAPIError{StatusCode: http.StatusForbidden, Status: "403 Forbidden"},
+			wantMember: WriteAccessLikely,
+			wantCommit: WriteAccessUnknown,
+		},
+		{
+			name:       "server error",
+			err:        &GitHubAPIError{StatusCode: http.StatusBadGateway, Status: "502 Bad Gateway"},
+			wantMember: WriteAccessUnknown,
+			wantCommit: WriteAccessUnknown,
+		},
+		{
+			name:       "network error",
+			err:        errors.New("connection reset"),
+			wantMember: WriteAccessUnknown,
+			wantCommit: WriteAccessUnknown,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Client{
+				github:          &permissionErrorClient{mockGithubClient: &mockGithubClient{}, err: tt.err},
+				logger:          slog.Default(),
+				permissionCache: &permissionCache{memory: make(map[string]permissionEntry)},
+			}
+			user := &githubUser{Login: "someone"}
+			if got := c.writeAccess(context.Background(), "owner", "repo", user, "MEMBER"); got != tt.wantMember {
+				t.Errorf("writeAccess(MEMBER) = %d, want %d", got, tt.wantMember)
+			}
+			if got := c.commitWriteAccess(context.Background(), "owner", "repo", user); got != tt.wantCommit {
+				t.Errorf("commitWriteAccess() = %d, want %d", got, tt.wantCommit)
+			}
+		})
+	}
+}
+
+func TestWriteAccessFromAssociation(t *testing.T) {
+	tests := []struct {
+		association string
+		lookup      bool
+		want        int
+		wantCalls   int
+	}{
+		{association: "OWNER", lookup: true, want: WriteAccessDefinitely},
+		{association: "COLLABORATOR", lookup: true, want: WriteAccessDefinitely},
+		// Organization members may have only read access, so they are confirmed via the API
+		{association: "MEMBER", lookup: true, want: WriteAccessUnlikely, wantCalls: 1},
+		{association: "MEMBER", lookup: false, want: WriteAccessUnknown},
+		// Anyone with write access is a member or collaborator, so these need no lookup
+		{association: "CONTRIBUTOR", lookup: true, want: WriteAccessUnlikely},
+		{association: "NONE", lookup: true, want: WriteAccessUnlikely},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s lookup=%v", tt.association, tt.lookup), func(t *testing.T) {
+			mock := &mockGithubClient{responses: map[string]any{
+				"/repos/owner/repo/collaborators/someone/permission": "read",
+			}}
+			c := NewClient("token", WithWriteAccessLookup(tt.lookup))
+			c.github = mock
+
+			if got := c.writeAccess(context.Background(), "owner", "repo", &githubUser{Login: "someone"}, tt.association); got != tt.want {
+				t.Errorf("writeAccess() = %d, want %d", got, tt.want)
+			}
+			if len(mock.calls) != tt.wantCalls {
+				t.Errorf("made %d API calls (%q), want %d", len(mock.calls), mock.calls, tt.wantCalls)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package prx
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// WithCollaboratorListing resolves write access by listing the repository's
+// collaborators once, a page per 100 people, instead of asking about each user
+// separately. Users missing from the listing are still looked up one at a time.
+// Listing collaborators needs push access to the repository; without it, every
+// user is looked up individually as usual.
+func WithCollaboratorListing() Option {
+	return func(c *Client) {
+		c.collaboratorListing = true
+	}
+}
+
+// collaboratorList is the result of listing one repository's collaborators.
+type collaboratorList struct {
+	mu          sync.Mutex
+	listedAt    time.Time
+	permissions map[string]string // Lowercased login -> "admin", "write", or "read"
+}
+
+// githubCollaborator is an entry in the repository collaborators listing.
+type githubCollaborator struct {
+	Login       string `json:"login"`
+	Permissions struct {
+		Admin    bool `json:"admin"`
+		Maintain bool `json:"maintain"`
+		Push     bool `json:"push"`
+	} `json:"permissions"`
+}
+
+// permission returns the collaborator's access in the vocabulary of the
+// per-user permission endpoint, which reports maintainers as "write".
+func (g *githubCollaborator) permission() string {
+	switch {
+	case g.Permissions.Admin:
+		return "admin"
+	case g.Permissions.Maintain, g.Permissions.Push:
+		return "write"
+	default:
+		return "read"
+	}
+}
+
+// collaboratorPermission looks username up in the repository's collaborator listing,
+// fetching the listing on first use and again once cached permissions would have
+// expired. It reports false when the user is not listed or the listing failed.
+func (c *Client) collaboratorPermission(ctx context.Context, owner, repo, username string) (string, bool) {
+	v, _ := c.collaborators.LoadOrStore(owner+"/"+repo, &collaboratorList{})
+	list, ok := v.(*collaboratorList)
+	if !ok {
+		return "", false
+	}
+	list.mu.Lock()
+	defer list.mu.Unlock()
+	if time.Since(list.listedAt) > c.permissionCache.expiry() {
+		list.permissions = c.listCollaborators(ctx, owner, repo)
+		list.listedAt = time.Now()
+	}
+	perm, ok := list.permissions[strings.ToLower(username)]
+	return perm, ok
+}
+
+// listCollaborators fetches every collaborator's permission and stores them in the
+// permission cache. It returns nil if the listing fails.
+func (c *Client) listCollaborators(ctx context.Context, owner, repo string) map[string]string {
+	byLogin := make(map[string]string)
+	path := fmt.Sprintf("/repos/%s/%s/collaborators?affiliation=all", owner, repo)
+	err := paginate(ctx, c, path, func(collaborator *githubCollaborator) error {
+		byLogin[collaborator.Login] = collaborator.permission()
+		return nil
+	})
+	if err != nil {
+		c.logger.InfoContext(ctx, "unable to list collaborators, checking permissions per user",
+			"owner", owner, "repo", repo, "error", err)
+		return nil
+	}
+
+	if err := c.permissionCache.setAll(owner, repo, byLogin); err != nil {
+		c.logger.WarnContext(ctx, "failed to cache permissions", "error", err)
+	}
+	permissions := make(map[string]string, len(byLogin))
+	for login, perm := range byLogin {
+		permissions[strings.ToLower(login)] = perm
+	}
+	c.logger.DebugContext(ctx, "listed collaborators", "owner", owner, "repo", repo, "count", len(permissions))
+	return permissions
+}
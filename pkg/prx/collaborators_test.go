@@ -0,0 +1,80 @@
+package prx
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestCollaboratorListing(t *testing.T) {
+	users := []string{"admin-user", "writer", "Reader", "outsider"}
+	tests := []struct {
+		name            string
+		listing         bool
+		wantListings    int
+		wantPerUser     int
+		wantWriteAccess []int
+	}{
+		{
+			name:            "per-user lookups by default",
+			wantPerUser:     4,
+			wantWriteAccess: []int{WriteAccessDefinitely, WriteAccessDefinitely, WriteAccessUnlikely, WriteAccessUnlikely},
+		},
+		{
+			name:            "one listing, per-user lookup only for non-collaborators",
+			listing:         true,
+			wantListings:    1,
+			wantPerUser:     1,
+			wantWriteAccess: []int{WriteAccessDefinitely, WriteAccessDefinitely, WriteAccessUnlikely, WriteAccessUnlikely},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collaborators := []map[string]any{
+				{"login": "admin-user", "permissions": map[string]bool{"admin": true, "push": true, "pull": true}},
+				{"login": "writer", "permissions": map[string]bool{"push": true, "pull": true}},
+				{"login": "reader", "permissions": map[string]bool{"pull": true}},
+			}
+			mock := &mockGithubClient{
+				responses: map[string]any{
+					"/repos/owner/repo/collaborators?affiliation=all&page=1&per_page=100": collaborators,
+					"/repos/owner/repo/collaborators/admin-user/permission":               "admin",
+					"/repos/owner/repo/collaborators/writer/permission":                   "write",
+					"/repos/owner/repo/collaborators/Reader/permission":                   "read",
+					"/repos/owner/repo/collaborators/outsider/permission":                 "none",
+				},
+			}
+			c := &Client{
+				github:              mock,
+				logger:              slog.Default(),
+				permissionCache:     &permissionCache{memory: make(map[string]permissionEntry)},
+				collaboratorListing: tt.listing,
+			}
+
+			for i, login := range users {
+				// Each user is asked about twice; the second answer must come from the cache.
+				for range 2 {
+					got := c.writeAccess(context.Background(), "owner", "repo", &githubUser{Login: login}, "MEMBER")
+					if got != tt.wantWriteAccess[i] {
+						t.Errorf("writeAccess(%s) = %d, want %d", login, got, tt.wantWriteAccess[i])
+					}
+				}
+			}
+
+			var listings, perUser int
+			for _, call := range mock.calls {
+				switch {
+				case strings.Contains(call, "/collaborators?"):
+					listings++
+				case strings.HasSuffix(call, "/permission"):
+					perUser++
+				}
+			}
+			if listings != tt.wantListings || perUser != tt.wantPerUser {
+				t.Errorf("got %d listings and %d per-user lookups, want %d and %d (calls: %q)",
+					listings, perUser, tt.wantListings, tt.wantPerUser, mock.calls)
+			}
+		})
+	}
+}
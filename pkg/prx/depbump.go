@@ -0,0 +1,63 @@
+package prx
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// defaultDependencyBots are the accounts WithDependencyBumpCompaction recognizes by default.
+var defaultDependencyBots = []string{"dependabot[bot]", "renovate[bot]"}
+
+// versionBumpTitle matches the titles dependency bots use, such as
+// "Bump golang.org/x/net from 0.17.0 to 0.23.0" or "chore(deps): update module foo to v2".
+var versionBumpTitle = regexp.MustCompile(`(?i)^(\w+(\([^)]*\))?!?:\s*)?(bump|update)\b.*\bto\s+v?\d`)
+
+// isDependencyBump reports whether a pull request looks like an automated version bump:
+// opened by one of bots and titled like one.
+func isDependencyBump(author, title string, bots []string) bool {
+	return slices.ContainsFunc(bots, func(bot string) bool { return strings.EqualFold(bot, author) }) &&
+		versionBumpTitle.MatchString(strings.TrimSpace(title))
+}
+
+// compactDependencyBump reduces a version bump's timeline to its lifecycle:
+// the opening, the final result of each check, and the merge or close.
+// events must be sorted by timestamp.
+func compactDependencyBump(events []Event) []Event {
+	latestCheck := make(map[string]int) // kind+name -> index of the last result
+	for i := range events {
+		if events[i].Kind == EventKindStatusCheck || events[i].Kind == EventKindCheckRun {
+			latestCheck[events[i].Kind+"\x00"+events[i].Body] = i
+		}
+	}
+
+	var compact []Event
+	for i := range events {
+		switch events[i].Kind {
+		case "pr_opened", EventKindPRMerged, "pr_closed":
+			compact = append(compact, events[i])
+		case EventKindStatusCheck, EventKindCheckRun:
+			if latestCheck[events[i].Kind+"\x00"+events[i].Body] == i {
+				compact = append(compact, events[i])
+			}
+		}
+	}
+	return compact
+}
+
+// compactIfDependencyBump applies WithDependencyBumpCompaction: when the pull request
+// is a dependency bump, it is marked as one and its events are compacted.
+func (c *Client) compactIfDependencyBump(ctx context.Context, pr *PullRequest, events []Event) []Event {
+	if len(c.dependencyBots) == 0 || !isDependencyBump(pr.Author, pr.Title, c.dependencyBots) {
+		return events
+	}
+	pr.DependencyBump = true
+	compact := compactDependencyBump(events)
+	c.logger.DebugContext(ctx, "compacted dependency bump timeline",
+		"pr", pr.Number,
+		"author", pr.Author,
+		"events", len(events),
+		"kept", len(compact))
+	return compact
+}
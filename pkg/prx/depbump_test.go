@@ -0,0 +1,127 @@
+package prx
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestIsDependencyBump(t *testing.T) {
+	tests := []struct {
+		author string
+		title  string
+		want   bool
+	}{
+		{"dependabot[bot]", "Bump golang.org/x/net from 0.17.0 to 0.23.0", true},
+		{"dependabot[bot]", "build(deps): bump actions/checkout from 3 to 4", true},
+		{"renovate[bot]", "chore(deps): update module github.com/foo/bar to v2", true},
+		{"Renovate[bot]", "Update dependency eslint to v9.1.0", true},
+		{"dependabot[bot]", "Bump the go group across 1 directory with 3 updates", false},
+		{"alice", "Bump golang.org/x/net from 0.17.0 to 0.23.0", false},
+		{"renovate[bot]", "Configure Renovate", false},
+	}
+
+	for _, tt := range tests {
+		if got := isDependencyBump(tt.author, tt.title, defaultDependencyBots); got != tt.want {
+			t.Errorf("isDependencyBump(%q, %q) = %v, want %v", tt.author, tt.title, got, tt.want)
+		}
+	}
+}
+
+func TestDependencyBumpCompaction(t *testing.T) {
+	base := time.Now().Add(-time.Hour)
+	at := func(minutes int) githubTime { return githubTime{Time: base.Add(time.Duration(minutes) * time.Minute)} }
+	dependabot := &githubUser{Login: "dependabot[bot]", Type: "Bot"}
+	pr := githubPullRequest{
+		Number:    1,
+		Title:     "Bump golang.org/x/net from 0.17.0 to 0.23.0",
+		State:     "closed",
+		CreatedAt: at(0),
+		User:      dependabot,
+		Merged:    true,
+		MergedAt:  at(30),
+		MergedBy:  &githubUser{Login: "github-actions[bot]", Type: "Bot"},
+		Head:      githubRef{SHA: "abc123"},
+	}
+	mock := &mockGithubClient{
+		responses: map[string]any{
+			"/repos/owner/repo/pulls/1": pr,
+			"/repos/owner/repo/pulls/1/commits?page=1&per_page=100": []*githubPullRequestCommit{
+				{SHA: "abc123", Author: dependabot, Commit: githubCommit{Message: "Bump golang.org/x/net"}},
+			},
+			"/repos/owner/repo/issues/1/comments?page=1&per_page=100": []*githubComment{
+				{User: &githubUser{Login: "codecov[bot]", Type: "Bot"}, Body: "Coverage unchanged", CreatedAt: at(5)},
+			},
+			"/repos/owner/repo/issues/1/timeline?page=1&per_page=100": []*githubTimelineEvent{
+				{Event: "labeled", Actor: dependabot, CreatedAt: at(1), Label: githubLabel{Name: "dependencies"}},
+			},
+			"/repos/owner/repo/commits/abc123/check-runs?page=1&per_page=100": githubCheckRuns{CheckRuns: []*githubCheckRun{
+				{Name: "test", Conclusion: "failure", CompletedAt: at(10)},
+				{Name: "test", Conclusion: "success", CompletedAt: at(20)},
+				{Name: "lint", Conclusion: "success", CompletedAt: at(15)},
+			}},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		opts     []Option
+		wantBump bool
+		want     []string
+	}{
+		{
+			name:     "compacted",
+			opts:     []Option{WithDependencyBumpCompaction()},
+			wantBump: true,
+			want: []string{
+				"pr_opened/",
+				"check_run/lint",
+				"check_run/test",
+				"pr_merged/",
+			},
+		},
+		{
+			name: "not configured for this bot",
+			opts: []Option{WithDependencyBumpCompaction("renovate[bot]")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &Client{
+				github:          mock,
+				logger:          slog.Default(),
+				permissionCache: &permissionCache{memory: make(map[string]permissionEntry)},
+			}
+			for _, opt := range tt.opts {
+				opt(client)
+			}
+
+			data, err := client.PullRequest(context.Background(), "owner", "repo", 1)
+			if err != nil {
+				t.Fatalf("PullRequest() error = %v", err)
+			}
+			if data.PullRequest.DependencyBump != tt.wantBump {
+				t.Errorf("DependencyBump = %v, want %v", data.PullRequest.DependencyBump, tt.wantBump)
+			}
+			if data.PullRequest.TestSummary == nil {
+				t.Error("summaries should still be computed from the full timeline")
+			}
+			if !tt.wantBump {
+				if len(data.Events) <= 4 {
+					t.Errorf("expected the full timeline, got %d events", len(data.Events))
+				}
+				return
+			}
+			var got []string
+			for _, e := range data.Events {
+				got = append(got, e.Kind+"/"+e.Body)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("events = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
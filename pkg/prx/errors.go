@@ -0,0 +1,40 @@
+package prx
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrTruncated means results are incomplete because a size or count limit was hit.
+// It is returned together with the partial results gathered before the limit,
+// so callers can decide whether to use them. Use errors.Is with one of the more
+// specific errors below to learn which limit was reached.
+var ErrTruncated = errors.New("results truncated")
+
+var (
+	// ErrMaxPages is returned when an endpoint has more pages than WithMaxPages allows.
+	ErrMaxPages = fmt.Errorf("%w: page limit reached", ErrTruncated)
+	// ErrMaxEvents is returned when a pull request has more events than WithMaxEvents allows.
+	ErrMaxEvents = fmt.Errorf("%w: event limit reached", ErrTruncated)
+	// ErrMaxBytes is returned when a single API response exceeds the 10MB size limit.
+	ErrMaxBytes = fmt.Errorf("%w: response size limit reached", ErrTruncated)
+)
+
+// partial returns events alongside err when err reports truncation, and drops
+// them otherwise, matching how fetchers report hard failures.
+func partial(events []Event, err error) ([]Event, error) {
+	if errors.Is(err, ErrTruncated) {
+		return events, err
+	}
+	return nil, err
+}
+
+// firstTruncation returns the first error in errs that reports truncation, or nil.
+func firstTruncation(errs []error) error {
+	for _, err := range errs {
+		if errors.Is(err, ErrTruncated) {
+			return err
+		}
+	}
+	return nil
+}
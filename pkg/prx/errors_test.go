@@ -0,0 +1,104 @@
+package prx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMaxPages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Every page claims there is another one.
+		w.Header().Set("Link", fmt.Sprintf(`<%s?page=2&per_page=100>; rel="next"`, r.URL.Path))
+		fmt.Fprintf(w, `[{"user":{"login":"dev"},"body":"page %s"}]`, r.URL.Query().Get("page"))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server, WithMaxPages(3))
+	events, err := client.comments(context.Background(), "owner", "repo", 1)
+	if !errors.Is(err, ErrMaxPages) || !errors.Is(err, ErrTruncated) {
+		t.Fatalf("comments() error = %v, want ErrMaxPages wrapping ErrTruncated", err)
+	}
+	if errors.Is(err, ErrMaxEvents) || errors.Is(err, ErrMaxBytes) {
+		t.Errorf("error %v matches the wrong limit", err)
+	}
+	if len(events) != 3 {
+		t.Errorf("expected 3 partial events, got %d", len(events))
+	}
+}
+
+func TestDefaultMaxPages(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Link", fmt.Sprintf(`<%s?page=2&per_page=100>; rel="next"`, r.URL.Path))
+		fmt.Fprint(w, `[{"user":{"login":"dev"},"body":"again"}]`)
+	}))
+	defer server.Close()
+
+	_, err := newTestClient(t, server).comments(context.Background(), "owner", "repo", 1)
+	if !errors.Is(err, ErrMaxPages) {
+		t.Fatalf("comments() error = %v, want ErrMaxPages", err)
+	}
+	if requests != defaultMaxPages {
+		t.Errorf("fetched %d pages, want %d", requests, defaultMaxPages)
+	}
+}
+
+func TestMaxEvents(t *testing.T) {
+	now := time.Now()
+	mock := &mockGithubClient{
+		responses: map[string]any{
+			"/repos/owner/repo/pulls/1": githubPullRequest{
+				Number:    1,
+				State:     "open",
+				CreatedAt: githubTime{Time: now.Add(-time.Hour)},
+				User:      &githubUser{Login: "author"},
+			},
+			"/repos/owner/repo/issues/1/comments?page=1&per_page=100": []*githubComment{
+				{User: &githubUser{Login: "a"}, Body: "one", CreatedAt: githubTime{Time: now.Add(-3 * time.Minute)}},
+				{User: &githubUser{Login: "b"}, Body: "two", CreatedAt: githubTime{Time: now.Add(-2 * time.Minute)}},
+				{User: &githubUser{Login: "c"}, Body: "three", CreatedAt: githubTime{Time: now.Add(-time.Minute)}},
+			},
+		},
+	}
+	client := &Client{
+		github:          mock,
+		logger:          slog.Default(),
+		permissionCache: &permissionCache{memory: make(map[string]permissionEntry)},
+	}
+	WithMaxEvents(2)(client)
+
+	data, err := client.PullRequest(context.Background(), "owner", "repo", 1)
+	if !errors.Is(err, ErrMaxEvents) || !errors.Is(err, ErrTruncated) {
+		t.Fatalf("PullRequest() error = %v, want ErrMaxEvents wrapping ErrTruncated", err)
+	}
+	if data == nil {
+		t.Fatal("expected partial data alongside ErrMaxEvents")
+	}
+	if len(data.Events) != 2 || data.Events[0].Kind != "pr_opened" || data.Events[1].Body != "one" {
+		t.Errorf("expected the 2 earliest events, got %+v", data.Events)
+	}
+}
+
+func TestMaxBytes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := `[{"user":{"login":"dev"},"body":"` + strings.Repeat("x", maxResponseSize) + `"}]`
+		if _, err := w.Write([]byte(body)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	_, err := client.comments(context.Background(), "owner", "repo", 1)
+	if !errors.Is(err, ErrMaxBytes) || !errors.Is(err, ErrTruncated) {
+		t.Fatalf("comments() error = %v, want ErrMaxBytes wrapping ErrTruncated", err)
+	}
+}
@@ -0,0 +1,103 @@
+package prx
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+)
+
+// ETagCache stores response bodies by request key so unchanged pages can be
+// revalidated with If-None-Match instead of downloaded again. GitHub does not
+// count 304 responses against the rate limit, which makes re-polling the same
+// pull request on a schedule much cheaper. Each body's pagination links are
+// stored as a second, small entry whose key ends in " page".
+//
+// Implementations must be safe for concurrent use.
+type ETagCache interface {
+	Get(key string) (etag string, body []byte, ok bool)
+	Set(key, etag string, body []byte)
+}
+
+// WithETagCache enables conditional requests backed by cache.
+func WithETagCache(cache ETagCache) Option {
+	return func(c *Client) {
+		c.etags = cache
+	}
+}
+
+// etagState wraps an ETagCache for conditional requests.
+type etagState struct {
+	cache  ETagCache
+	logger *slog.Logger
+}
+
+// etagPage is the pagination metadata saved next to a cached body, since a 304
+// does not reliably repeat the Link header. It lives in the ETagCache under
+// pageKey, so it is evicted and persisted along with the bodies.
+type etagPage struct {
+	NextPage int    `json:"next_page,omitempty"`
+	NextPath string `json:"next_path,omitempty"`
+}
+
+// pageKey is the cache key of the pagination metadata for the body under key.
+func pageKey(key string) string {
+	return key + " page"
+}
+
+// etagKey identifies a cached response. The media type is part of the key
+// because the same URL returns different bodies for different Accept headers.
+func etagKey(accept, apiURL string) string {
+	return accept + " " + apiURL
+}
+
+// conditional adds If-None-Match to req when a cached body exists for key,
+// returning that body for use if GitHub answers 304.
+func (e *etagState) conditional(req *http.Request, key string) []byte {
+	if e == nil || e.cache == nil {
+		return nil
+	}
+	etag, body, ok := e.cache.Get(key)
+	if !ok || etag == "" {
+		return nil
+	}
+	req.Header.Set("If-None-Match", etag)
+	return body
+}
+
+// store records a fresh 200 response for later revalidation.
+func (e *etagState) store(ctx context.Context, key string, header http.Header, body []byte, resp *githubResponse) {
+	if e == nil || e.cache == nil {
+		return
+	}
+	etag := header.Get("ETag")
+	if etag == "" {
+		return
+	}
+	e.cache.Set(key, etag, body)
+	if page, err := json.Marshal(etagPage{NextPage: resp.NextPage, NextPath: resp.NextPath}); err == nil {
+		e.cache.Set(pageKey(key), etag, page)
+	}
+	e.logger.DebugContext(ctx, "cached response for conditional requests", "key", key, "etag", etag)
+}
+
+// response fills in resp, parsed from a 304, with the pagination metadata saved
+// alongside the cached body for key. Metadata that was evicted, or that belongs to
+// a different version of the body, is ignored and resp is returned as is.
+func (e *etagState) response(key string, resp *githubResponse) *githubResponse {
+	etag, _, ok := e.cache.Get(key)
+	if !ok {
+		return resp
+	}
+	pageETag, data, ok := e.cache.Get(pageKey(key))
+	if !ok || pageETag != etag {
+		return resp
+	}
+	var page etagPage
+	if err := json.Unmarshal(data, &page); err != nil {
+		return resp
+	}
+	resp.NextPage = page.NextPage
+	resp.NextPath = page.NextPath
+	return resp
+}
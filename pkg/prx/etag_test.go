@@ -0,0 +1,137 @@
+package prx
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type mapETagCache struct {
+	mu      sync.Mutex
+	entries map[string]mapETagEntry
+}
+
+type mapETagEntry struct {
+	etag string
+	body []byte
+}
+
+func (m *mapETagCache) Get(key string) (string, []byte, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.entries[key]
+	return e.etag, e.body, ok
+}
+
+func (m *mapETagCache) Set(key, etag string, body []byte) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries[key] = mapETagEntry{etag: etag, body: body}
+}
+
+func TestETagConditionalRequests(t *testing.T) {
+	var full, notModified int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		full++
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Link", `<`+r.URL.Path+`?page=2>; rel="next"`)
+		if _, err := w.Write([]byte(`[{"sha":"abc"}]`)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	cache := &mapETagCache{entries: make(map[string]mapETagEntry)}
+	gc, ok := newTestClient(t, server, WithETagCache(cache)).github.(*githubClient)
+	if !ok {
+		t.Fatal("unexpected github client type")
+	}
+
+	for i := range 3 {
+		data, resp, err := gc.doRequest(context.Background(), "/repos/o/r/pulls/1/commits")
+		if err != nil {
+			t.Fatalf("request %d: %v", i, err)
+		}
+		if string(data) != `[{"sha":"abc"}]` {
+			t.Errorf("request %d body = %s", i, data)
+		}
+		if resp.NextPage != 2 {
+			t.Errorf("request %d NextPage = %d, want 2", i, resp.NextPage)
+		}
+	}
+	if full != 1 || notModified != 2 {
+		t.Errorf("full = %d, notModified = %d; want 1 and 2", full, notModified)
+	}
+
+	// Different media types are cached separately.
+	if _, _, err := gc.doRequest(withAccept(context.Background(), fullAccept), "/repos/o/r/pulls/1/commits"); err != nil {
+		t.Fatal(err)
+	}
+	if full != 2 {
+		t.Errorf("full = %d after Accept change, want 2", full)
+	}
+}
+
+func TestETagPaginationSharedCache(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Link", `<`+r.URL.Path+`?page=2>; rel="next"`)
+		if _, err := w.Write([]byte(`[{"sha":"abc"}]`)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name         string
+		dropPage     bool
+		wantNextPage int
+	}{
+		{name: "metadata in cache", wantNextPage: 2},
+		{name: "metadata evicted", dropPage: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := &mapETagCache{entries: make(map[string]mapETagEntry)}
+			first, ok := newTestClient(t, server, WithETagCache(cache)).github.(*githubClient)
+			if !ok {
+				t.Fatal("unexpected github client type")
+			}
+			if _, _, err := first.doRequest(context.Background(), "/repos/o/r/pulls/1/commits"); err != nil {
+				t.Fatal(err)
+			}
+			if tt.dropPage {
+				for key := range cache.entries {
+					if strings.HasSuffix(key, " page") {
+						delete(cache.entries, key)
+					}
+				}
+			}
+
+			// A fresh client, as after a restart, revalidates against the shared cache.
+			second, ok := newTestClient(t, server, WithETagCache(cache)).github.(*githubClient)
+			if !ok {
+				t.Fatal("unexpected github client type")
+			}
+			data, resp, err := second.doRequest(context.Background(), "/repos/o/r/pulls/1/commits")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != `[{"sha":"abc"}]` || resp.NextPage != tt.wantNextPage {
+				t.Errorf("got body %s and NextPage %d, want the cached body and %d", data, resp.NextPage, tt.wantNextPage)
+			}
+		})
+	}
+}
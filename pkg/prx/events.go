@@ -30,6 +30,7 @@ const (
 
 	// PR state events.
 	EventKindPRMerged       = "pr_merged"
+	EventKindMerged         = "merged" // Timeline counterpart of pr_merged
 	EventKindReadyForReview = "ready_for_review"
 	EventKindConvertToDraft = "convert_to_draft"
 	EventKindClosed         = "closed"
@@ -97,13 +98,16 @@ const (
 	// Check/Status events (not from timeline but from other APIs).
 	EventKindStatusCheck = "status_check"
 	EventKindCheckRun    = "check_run"
+	// EventKindCombinedStatus is the overall state of the head commit's statuses (WithCombinedStatus).
+	EventKindCombinedStatus = "combined_status"
 )
 
 // WriteAccess constants for the Event.WriteAccess field.
 const (
+	WriteAccessUnknown    = -3 // Permission lookup failed or was skipped; below zero so it never counts as write access
 	WriteAccessNo         = -2 // User confirmed to not have write access
 	WriteAccessUnlikely   = -1 // User unlikely to have write access (CONTRIBUTOR, NONE, etc.)
-	WriteAccessNA         = 0  // Not applicable/not set (omitted from JSON)
+	WriteAccessNA         = 0  // Not applicable, such as events without a user (omitted from JSON)
 	WriteAccessLikely     = 1  // User likely has write access but unable to confirm (MEMBER with 403 API response)
 	WriteAccessDefinitely = 2  // User definitely has write access (OWNER, COLLABORATOR, or confirmed via API)
 )
@@ -128,6 +132,8 @@ type Event struct {
 	// - For review_requested: the reviewer username or team name
 	// - For labeled/unlabeled: the label name
 	// - For milestoned/demilestoned: the milestone name
+	// - For cross-referenced: the mentioning issue or PR as owner/repo#number
+	// - For referenced: the SHA of the mentioning commit
 	Target string `json:"target,omitempty"`
 
 	// TargetIsBot indicates if the target is an automated bot account
@@ -136,32 +142,114 @@ type Event struct {
 
 	// Outcome stores the result of the event
 	// - For checks: "success", "failure", "pending", "neutral", "cancelled", "skipped", "timed_out", "action_required"
-	// - For reviews: "approved", "changes_requested", "commented"
+	// - For reviews: "approved", "changes_requested", "commented", always lower case
+	// - For review_dismissed: the dismissed review's state, in the same form
 	// - For status checks: "success", "failure", "pending", "error"
 	Outcome string `json:"outcome,omitempty"`
 
 	// Body contains the main content of the event
-	// - For comments/reviews: the text content (truncated to 256 chars)
+	// - For comments/reviews: the text content
+	// - For pr_opened: the pull request description
 	// - For commits: the commit message
+	// Those texts are cut to WithBodyLimit bytes (256 by default), ending in "…" when shortened
 	// - For check runs/status checks: the check name
-	// - For labeled/unlabeled: the label name
 	Body string `json:"body,omitempty"`
 
 	// Question indicates if this comment/review appears to be asking a question
 	Question bool `json:"question,omitempty"`
 
 	// WriteAccess indicates the actor's repository permissions
+	// - WriteAccessUnknown (-3): The permission lookup failed or was skipped
 	// - WriteAccessNo (-2): User confirmed to not have write access
 	// - WriteAccessUnlikely (-1): User unlikely to have write access
-	// - WriteAccessNA (0): Not applicable (omitted from JSON)
+	// - WriteAccessNA (0): Not applicable, such as events without a user (omitted from JSON)
 	// - WriteAccessLikely (1): User likely has write access but unable to confirm
 	// - WriteAccessDefinitely (2): User definitely has write access
 	WriteAccess int `json:"write_access,omitempty"`
+
+	// ReviewID links reviews and their inline comments
+	// - For reviews: the review's own ID
+	// - For review comments: the ID of the review submission that delivered the comment
+	// - For review_dismissed: the ID of the dismissed review
+	ReviewID int64 `json:"review_id,omitempty"`
+
+	// LabelColor and LabelDescription describe the label for labeled/unlabeled events
+	// - LabelColor: hex color without the leading "#", e.g. "d73a4a"
+	// - LabelDescription: empty when the label has no description
+	LabelColor       string `json:"label_color,omitempty"`
+	LabelDescription string `json:"label_description,omitempty"`
+
+	// URL links to more detail about the event
+	// - For check runs: the details URL on the CI provider, or the run on GitHub
+	// - For status checks: the target URL set by the CI provider
+	URL string `json:"url,omitempty"`
+
+	// Elapsed is how long a completed check run took from start to finish
+	// (nanoseconds in JSON); 0 while it is still running
+	Elapsed time.Duration `json:"elapsed,omitempty"`
+
+	// ExternalID is the CI provider's identifier for a check run
+	ExternalID string `json:"external_id,omitempty"`
+
+	// CheckSuiteID identifies the check suite a check run belongs to; runs from the
+	// same CI provider on the same commit share a suite
+	CheckSuiteID int64 `json:"check_suite_id,omitempty"`
+
+	// BodyHTML is GitHub's rendered HTML for comment bodies (only with WithRenderedBodies)
+	BodyHTML string `json:"body_html,omitempty"`
+
+	// CommentID and InReplyTo thread review comments
+	// - CommentID: the review comment's own ID
+	// - InReplyTo: the ID of the comment that started the thread; 0 for the first comment
+	CommentID int64 `json:"comment_id,omitempty"`
+	InReplyTo int64 `json:"in_reply_to,omitempty"`
+
+	// Path and Line locate a review comment in the diff
+	// - Path: the file, relative to the repository root
+	// - Line: the line in the current diff; 0 once the comment is outdated
+	// - OriginalLine: the line in the diff the comment was written against
+	Path         string `json:"path,omitempty"`
+	Line         int    `json:"line,omitempty"`
+	OriginalLine int    `json:"original_line,omitempty"`
+
+	// Outdated marks a review comment whose code has since changed, so it no longer
+	// appears in the pull request's current diff
+	Outdated bool `json:"outdated,omitempty"`
+
+	// DiffHunk is the code a review comment is attached to (only with WithDiffHunks)
+	DiffHunk string `json:"diff_hunk,omitempty"`
+
+	// Reactions counts emoji reactions by GitHub's name for them ("+1", "heart", "rocket", ...)
+	// on comments and review comments (only with WithReactions)
+	Reactions map[string]int `json:"reactions,omitempty"`
+
+	// RenamedFrom is the previous title for renamed events, whose Body holds the new title
+	RenamedFrom string `json:"renamed_from,omitempty"`
+
+	// SHA is the commit hash an event refers to
+	// - For commits: the commit itself
+	// - For merged and closed: the merge commit, or the commit that closed the PR
+	// - For head_ref_force_pushed: the new head commit
+	// - For referenced: the commit that mentioned the PR
+	SHA string `json:"sha,omitempty"`
+
+	// CoAuthors lists the "Name <email>" of each Co-authored-by trailer on a commit
+	CoAuthors []string `json:"co_authors,omitempty"`
+
+	// Verified is set on commits whose signature GitHub verified (GPG, SSH, or S/MIME)
+	Verified bool `json:"verified,omitempty"`
+
+	// Source is the issue number an event came from when it was merged in from a
+	// linked issue (see WithLinkedIssues); 0 for the pull request's own events
+	Source int `json:"source,omitempty"`
+
+	// TraceID is the caller-supplied tag from ContextWithTraceID, e.g. a webhook delivery ID
+	TraceID string `json:"trace_id,omitempty"`
 }
 
 // createEvent is a helper function to create an Event with common fields.
-func createEvent(kind string, timestamp time.Time, user *githubUser, body string) Event {
-	body = truncate(body, 256)
+// body should already be shortened with Client.truncateBody.
+func (c *Client) createEvent(kind string, timestamp time.Time, user *githubUser, body string) Event {
 	event := Event{
 		Kind:      kind,
 		Timestamp: timestamp,
@@ -170,7 +258,7 @@ func createEvent(kind string, timestamp time.Time, user *githubUser, body string
 	}
 	if user != nil {
 		event.Actor = user.Login
-		event.Bot = isBot(user)
+		event.Bot = c.isBot(user)
 	}
 	return event
 }
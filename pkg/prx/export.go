@@ -0,0 +1,101 @@
+package prx
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// csvHeader lists the columns written by EncodeCSV, in order.
+var csvHeader = []string{
+	"kind",
+	"timestamp",
+	"actor",
+	"bot",
+	"target",
+	"outcome",
+	"question",
+	"write_access",
+	"body",
+}
+
+// EncodeCSV writes events to w as CSV with a header row and one row per event.
+// Only scalar fields are included; fields containing commas, quotes, or
+// newlines are quoted as described in RFC 4180.
+func EncodeCSV(w io.Writer, events []Event) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return fmt.Errorf("writing csv header: %w", err)
+	}
+
+	for i := range events {
+		e := &events[i]
+		row := []string{
+			e.Kind,
+			e.Timestamp.UTC().Format(time.RFC3339),
+			e.Actor,
+			strconv.FormatBool(e.Bot),
+			e.Target,
+			e.Outcome,
+			strconv.FormatBool(e.Question),
+			strconv.Itoa(e.WriteAccess),
+			e.Body,
+		}
+		if err := cw.Write(row); err != nil {
+			return fmt.Errorf("writing csv row: %w", err)
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// EventSchemaVersion identifies the layout of documents written by EncodeJSON. It is
+// raised when a field is renamed or removed or changes meaning; new fields may be
+// added without raising it.
+const EventSchemaVersion = 1
+
+// eventDocument is the top-level object written by EncodeJSON.
+type eventDocument struct {
+	Version int     `json:"version"`
+	Events  []Event `json:"events"`
+}
+
+// EncodeJSON writes events to w as an indented JSON document of the form
+// {"version": EventSchemaVersion, "events": [...]}, with each event encoded under
+// its usual field names. Timestamps are written in UTC and map keys in sorted order,
+// so the same events always produce the same bytes and stored timelines diff cleanly.
+func EncodeJSON(w io.Writer, events []Event) error {
+	doc := eventDocument{Version: EventSchemaVersion, Events: make([]Event, len(events))}
+	for i, e := range events {
+		e.Timestamp = e.Timestamp.UTC()
+		doc.Events[i] = e
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("writing json: %w", err)
+	}
+	return nil
+}
+
+// EncodeNDJSON writes each event received from events to w as one line of JSON, in
+// the same form as the entries of EncodeJSON, until events is closed. It pairs with
+// StreamPullRequestEvents to write events as they arrive. If a write fails it returns
+// at once without draining events, so cancel the stream's context to stop it.
+func EncodeNDJSON(w io.Writer, events <-chan Event) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	for e := range events {
+		e.Timestamp = e.Timestamp.UTC()
+		if err := enc.Encode(e); err != nil {
+			return fmt.Errorf("writing ndjson: %w", err)
+		}
+	}
+	return nil
+}
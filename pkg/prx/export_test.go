@@ -0,0 +1,153 @@
+package prx
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite golden files in testdata")
+
+func TestEncodeCSV(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	events := []Event{
+		{
+			Kind:        "comment",
+			Timestamp:   ts,
+			Actor:       "alice",
+			Body:        "first, second\nthird line",
+			Question:    true,
+			WriteAccess: WriteAccessDefinitely,
+		},
+		{
+			Kind:      "labeled",
+			Timestamp: ts.Add(time.Hour),
+			Actor:     "dependabot[bot]",
+			Bot:       true,
+			Target:    "dependencies",
+			Body:      `say "hi"`,
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := EncodeCSV(&buf, events); err != nil {
+		t.Fatalf("EncodeCSV() error = %v", err)
+	}
+
+	if !strings.HasPrefix(buf.String(), "kind,timestamp,actor,bot,target,outcome,question,write_access,body\n") {
+		t.Errorf("unexpected header: %q", strings.SplitN(buf.String(), "\n", 2)[0])
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("reading csv: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("expected 3 records, got %d", len(records))
+	}
+
+	want := [][]string{
+		{"comment", "2024-01-02T03:04:05Z", "alice", "false", "", "", "true", "2", "first, second\nthird line"},
+		{"labeled", "2024-01-02T04:04:05Z", "dependabot[bot]", "true", "dependencies", "", "false", "0", `say "hi"`},
+	}
+	for i, row := range want {
+		got := records[i+1]
+		for j := range row {
+			if got[j] != row[j] {
+				t.Errorf("row %d column %s = %q, want %q", i+1, csvHeader[j], got[j], row[j])
+			}
+		}
+	}
+}
+
+func TestEncodeJSONGolden(t *testing.T) {
+	est := time.FixedZone("EST", -5*60*60)
+	events := []Event{
+		{
+			Kind:        "pr_opened",
+			Timestamp:   time.Date(2024, 1, 2, 4, 0, 0, 0, est),
+			Actor:       "alice",
+			Body:        "Adds <retry> & backoff",
+			WriteAccess: WriteAccessDefinitely,
+		},
+		{
+			Kind:        "review_comment",
+			Timestamp:   time.Date(2024, 1, 2, 10, 30, 0, 0, time.UTC),
+			Actor:       "bob",
+			Body:        "Why three attempts?",
+			Question:    true,
+			WriteAccess: WriteAccessUnknown,
+			Path:        "retry.go",
+			Line:        12,
+			Reactions:   map[string]int{"rocket": 1, "+1": 2},
+		},
+		{
+			Kind:      "check_run",
+			Timestamp: time.Date(2024, 1, 2, 11, 0, 0, 0, time.UTC),
+			Actor:     "github",
+			Bot:       true,
+			Outcome:   "failure",
+			Body:      "test",
+			Elapsed:   90 * time.Second,
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := EncodeJSON(&buf, events); err != nil {
+		t.Fatalf("EncodeJSON() error = %v", err)
+	}
+
+	golden := filepath.Join("testdata", "events.golden.json")
+	if *updateGolden {
+		if err := os.WriteFile(golden, buf.Bytes(), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("reading golden file (run with -update to create it): %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Errorf("EncodeJSON() output differs from %s:\n%s", golden, buf.String())
+	}
+}
+
+func TestEncodeNDJSON(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	sent := []Event{
+		{Kind: "comment", Timestamp: ts, Actor: "alice", Body: "multi\nline body"},
+		{Kind: "labeled", Timestamp: ts.Add(time.Minute), Actor: "bob", Target: "bug"},
+		{Kind: "check_run", Timestamp: ts.Add(time.Hour), Actor: "github", Bot: true, Outcome: "success"},
+	}
+	events := make(chan Event, len(sent))
+	for _, e := range sent {
+		events <- e
+	}
+	close(events)
+
+	var buf bytes.Buffer
+	if err := EncodeNDJSON(&buf, events); err != nil {
+		t.Fatalf("EncodeNDJSON() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != len(sent) {
+		t.Fatalf("got %d lines, want %d:\n%s", len(lines), len(sent), buf.String())
+	}
+	for i, line := range lines {
+		var got Event
+		if err := json.Unmarshal([]byte(line), &got); err != nil {
+			t.Fatalf("line %d is not valid JSON: %v\n%s", i+1, err, line)
+		}
+		if !reflect.DeepEqual(got, sent[i]) {
+			t.Errorf("line %d = %+v, want %+v", i+1, got, sent[i])
+		}
+	}
+}
@@ -2,35 +2,74 @@ package prx
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 )
 
 const maxPerPage = 100
 
-// paginate fetches all pages of results from a GitHub API endpoint.
-// The fetch function should unmarshal the response and return the next page number.
+// errStopPagination is returned by a paginate callback that has seen enough.
+// Pagination ends there and paginate returns nil; no further pages are fetched.
+var errStopPagination = errors.New("stop pagination")
+
+// paginate fetches all pages of results from a GitHub API endpoint that returns a JSON array.
+// It follows the next link reported by the API, falling back to the page number.
+// If the client's page limit is reached with more pages remaining, it returns ErrMaxPages.
+// process may return errStopPagination to end early without error.
 func paginate[T any](ctx context.Context, c *Client, path string, process func(*T) error) error {
-	page := 1
-	for {
-		pagePath := fmt.Sprintf("%s?page=%d&per_page=%d", path, page, maxPerPage)
+	return followPages(ctx, c, path, func(pagePath string) (*githubResponse, error) {
 		var items []T
 		resp, err := c.github.get(ctx, pagePath, &items)
 		if err != nil {
-			return err
+			return nil, err
 		}
-
 		for i := range items {
 			if err := process(&items[i]); err != nil {
-				return err
+				return nil, err
 			}
 		}
+		return resp, nil
+	})
+}
 
-		if resp.NextPage == 0 {
-			break
+// followPages calls fetch for each page of path until the API reports no next page,
+// for endpoints like check runs that wrap their results in an object.
+func followPages(ctx context.Context, c *Client, path string, fetch func(pagePath string) (*githubResponse, error)) error {
+	pagePath := pageURL(path, 1)
+	for pages := 1; ; pages++ {
+		resp, err := fetch(pagePath)
+		if errors.Is(err, errStopPagination) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if c.maxPages > 0 && pages >= c.maxPages && (resp.NextPath != "" || resp.NextPage != 0) {
+			c.logger.WarnContext(ctx, "page limit reached, results are incomplete", "path", path, "max_pages", c.maxPages)
+			return fmt.Errorf("%w: %s has more than %d pages", ErrMaxPages, path, c.maxPages)
+		}
+
+		switch {
+		case resp.NextPath != "":
+			pagePath = resp.NextPath
+		case resp.NextPage != 0:
+			pagePath = pageURL(path, resp.NextPage)
+		default:
+			return nil
 		}
-		page = resp.NextPage
 	}
-	return nil
+}
+
+// pageURL adds page and per_page parameters to path, which may already have a query.
+func pageURL(path string, page int) string {
+	sep := "?"
+	if strings.Contains(path, "?") {
+		sep = "&"
+	}
+	return fmt.Sprintf("%s%spage=%d&per_page=%d", path, sep, page, maxPerPage)
 }
 
 func (c *Client) commits(ctx context.Context, owner, repo string, prNumber int) ([]Event, error) {
@@ -40,60 +79,103 @@ func (c *Client) commits(ctx context.Context, owner, repo string, prNumber int)
 	path := fmt.Sprintf("/repos/%s/%s/pulls/%d/commits", owner, repo, prNumber)
 
 	err := paginate(ctx, c, path, func(commit *githubPullRequestCommit) error {
-		event := Event{
-			Kind:      "commit",
-			Timestamp: commit.Commit.Author.Date,
-			Body:      truncate(commit.Commit.Message, 256),
-		}
-
-		if commit.Author != nil {
-			event.Actor = commit.Author.Login
-			event.Bot = isBot(commit.Author)
-		} else {
-			event.Actor = "unknown"
-		}
-
-		events = append(events, event)
+		events = appendEvent(ctx, events, c.commitEvent(ctx, owner, repo, commit))
 		return nil
 	})
 
 	if err != nil {
-		return nil, fmt.Errorf("fetching commits: %w", err)
+		return partial(events, fmt.Errorf("fetching commits: %w", err))
 	}
 
 	c.logger.DebugContext(ctx, "fetched commits", "count", len(events))
 	return events, nil
 }
 
+// commitEvent converts a pull request commit into an event.
+func (c *Client) commitEvent(ctx context.Context, owner, repo string, commit *githubPullRequestCommit) Event {
+	event := Event{
+		Kind:      "commit",
+		Timestamp: commit.Commit.Author.Date.Time,
+		Body:      c.truncateBody(commit.Commit.Message),
+		SHA:       commit.SHA,
+		Verified:  commit.Commit.Verification.Verified,
+		CoAuthors: coAuthors(commit.Commit.Message),
+	}
+
+	if commit.Author != nil {
+		event.Actor = commit.Author.Login
+		event.Bot = c.isBot(commit.Author)
+		event.WriteAccess = c.commitWriteAccess(ctx, owner, repo, commit.Author)
+	} else {
+		event.Actor = "unknown"
+	}
+	return event
+}
+
+// commitWriteAccess returns the write access level for a commit author.
+// Commits carry no author_association, so the (cached) permission API is the only signal.
+func (c *Client) commitWriteAccess(ctx context.Context, owner, repo string, user *githubUser) int {
+	// Deleted accounts are attributed to the "ghost" user, which has no permissions to look up.
+	if user == nil || user.Login == "" || user.Login == "ghost" {
+		return WriteAccessNA
+	}
+
+	perm, err := c.userPermissionCached(ctx, owner, repo, user.Login, "")
+	if err != nil {
+		c.logger.DebugContext(ctx, "unable to resolve commit author permission", "user", user.Login, "error", err)
+		return WriteAccessUnknown
+	}
+	switch perm {
+	case "admin", "maintain", "write":
+		return WriteAccessDefinitely
+	case "uncertain":
+		return WriteAccessUnknown
+	default:
+		return WriteAccessUnlikely
+	}
+}
+
 func (c *Client) comments(ctx context.Context, owner, repo string, prNumber int) ([]Event, error) {
 	c.logger.DebugContext(ctx, "fetching comments", "owner", owner, "repo", repo, "pr", prNumber)
 
 	var events []Event
-	path := fmt.Sprintf("/repos/%s/%s/issues/%d/comments", owner, repo, prNumber)
-
-	err := paginate(ctx, c, path, func(comment *githubComment) error {
-		body := truncate(comment.Body, 256)
-		event := Event{
-			Kind:        "comment",
-			Timestamp:   comment.CreatedAt,
-			Actor:       comment.User.Login,
-			Body:        body,
-			Question:    containsQuestion(body),
-			Bot:         isBot(comment.User),
-			WriteAccess: c.writeAccess(ctx, owner, repo, comment.User, comment.AuthorAssociation),
-		}
-		events = append(events, event)
+	path := c.sincePath(fmt.Sprintf("/repos/%s/%s/issues/%d/comments", owner, repo, prNumber))
+
+	err := paginate(c.commentContext(ctx), c, path, func(comment *githubComment) error {
+		events = appendEvent(ctx, events, c.commentEvent(ctx, owner, repo, comment))
 		return nil
 	})
 
 	if err != nil {
-		return nil, fmt.Errorf("fetching comments: %w", err)
+		return partial(events, fmt.Errorf("fetching comments: %w", err))
 	}
 
 	c.logger.DebugContext(ctx, "fetched comments", "count", len(events))
 	return events, nil
 }
 
+// commentContext returns the context to fetch comments with, asking GitHub
+// for rendered HTML alongside the markdown when WithRenderedBodies is set.
+func (c *Client) commentContext(ctx context.Context) context.Context {
+	if !c.renderedBodies {
+		return ctx
+	}
+	return withAccept(ctx, fullAccept)
+}
+
+// commentEvent converts an issue comment into an event.
+func (c *Client) commentEvent(ctx context.Context, owner, repo string, comment *githubComment) Event {
+	event := c.createEvent("comment", comment.CreatedAt.Time, comment.User, c.truncateBody(comment.Body))
+	if c.renderedBodies {
+		event.BodyHTML = comment.BodyHTML
+	}
+	if c.reactions {
+		event.Reactions = comment.Reactions.counts()
+	}
+	event.WriteAccess = c.writeAccess(ctx, owner, repo, comment.User, comment.AuthorAssociation)
+	return event
+}
+
 func (c *Client) reviews(ctx context.Context, owner, repo string, prNumber int) ([]Event, error) {
 	c.logger.DebugContext(ctx, "fetching reviews", "owner", owner, "repo", repo, "pr", prNumber)
 
@@ -104,24 +186,12 @@ func (c *Client) reviews(ctx context.Context, owner, repo string, prNumber int)
 		if review.State == "" {
 			return nil
 		}
-
-		body := truncate(review.Body, 256)
-		event := Event{
-			Kind:        "review",
-			Timestamp:   review.SubmittedAt,
-			Actor:       review.User.Login,
-			Body:        body,
-			Question:    containsQuestion(body),
-			Bot:         isBot(review.User),
-			Outcome:     review.State,
-			WriteAccess: c.writeAccess(ctx, owner, repo, review.User, review.AuthorAssociation),
-		}
-		events = append(events, event)
+		events = appendEvent(ctx, events, c.reviewEvent(ctx, owner, repo, review))
 		return nil
 	})
 
 	if err != nil {
-		return nil, fmt.Errorf("fetching reviews: %w", err)
+		return partial(events, fmt.Errorf("fetching reviews: %w", err))
 	}
 
 	c.logger.DebugContext(ctx, "fetched reviews", "count", len(events))
@@ -132,62 +202,104 @@ func (c *Client) reviewComments(ctx context.Context, owner, repo string, prNumbe
 	c.logger.DebugContext(ctx, "fetching review comments", "owner", owner, "repo", repo, "pr", prNumber)
 
 	var events []Event
-	path := fmt.Sprintf("/repos/%s/%s/pulls/%d/comments", owner, repo, prNumber)
-
-	err := paginate(ctx, c, path, func(comment *githubReviewComment) error {
-		body := truncate(comment.Body, 256)
-		event := Event{
-			Kind:        "review_comment",
-			Timestamp:   comment.CreatedAt,
-			Actor:       comment.User.Login,
-			Body:        body,
-			Question:    containsQuestion(body),
-			Bot:         isBot(comment.User),
-			WriteAccess: c.writeAccess(ctx, owner, repo, comment.User, comment.AuthorAssociation),
-		}
-		events = append(events, event)
+	path := c.sincePath(fmt.Sprintf("/repos/%s/%s/pulls/%d/comments", owner, repo, prNumber))
+
+	err := paginate(c.commentContext(ctx), c, path, func(comment *githubReviewComment) error {
+		events = appendEvent(ctx, events, c.reviewCommentEvent(ctx, owner, repo, comment))
 		return nil
 	})
 
 	if err != nil {
-		return nil, fmt.Errorf("fetching review comments: %w", err)
+		return partial(events, fmt.Errorf("fetching review comments: %w", err))
 	}
 
 	c.logger.DebugContext(ctx, "fetched review comments", "count", len(events))
 	return events, nil
 }
 
+// reviewOutcome returns a review state as an event Outcome. The REST and GraphQL
+// APIs report submitted reviews in upper case ("APPROVED") but dismissed reviews in
+// the timeline in lower case, so every review state is lowercased here.
+func reviewOutcome(state string) string {
+	return strings.ToLower(state) // "approved", "changes_requested", "commented", "dismissed"
+}
+
+// reviewEvent converts a submitted review into an event.
+func (c *Client) reviewEvent(ctx context.Context, owner, repo string, review *githubReview) Event {
+	event := c.createEvent("review", review.SubmittedAt.Time, review.User, c.truncateBody(review.Body))
+	event.Outcome = reviewOutcome(review.State)
+	event.ReviewID = review.ID
+	event.WriteAccess = c.writeAccess(ctx, owner, repo, review.User, review.AuthorAssociation)
+	return event
+}
+
+// reviewCommentEvent converts an inline review comment into an event.
+func (c *Client) reviewCommentEvent(ctx context.Context, owner, repo string, comment *githubReviewComment) Event {
+	event := c.createEvent("review_comment", comment.CreatedAt.Time, comment.User, c.truncateBody(comment.Body))
+	event.ReviewID = comment.PullRequestReviewID
+	event.CommentID = comment.ID
+	event.InReplyTo = comment.InReplyToID
+	event.Path = comment.Path
+	event.Outdated = comment.Position == nil
+	if comment.Line != nil {
+		event.Line = *comment.Line
+	}
+	if comment.OriginalLine != nil {
+		event.OriginalLine = *comment.OriginalLine
+	}
+	if c.renderedBodies {
+		event.BodyHTML = comment.BodyHTML
+	}
+	if c.diffHunks {
+		event.DiffHunk = truncate(comment.DiffHunk, maxDiffHunkLength)
+	}
+	if c.reactions {
+		event.Reactions = comment.Reactions.counts()
+	}
+	event.WriteAccess = c.writeAccess(ctx, owner, repo, comment.User, comment.AuthorAssociation)
+	return event
+}
+
 func (c *Client) timelineEvents(ctx context.Context, owner, repo string, prNumber int) ([]Event, error) {
 	c.logger.DebugContext(ctx, "fetching timeline events", "owner", owner, "repo", repo, "pr", prNumber)
 
 	var events []Event
 	path := fmt.Sprintf("/repos/%s/%s/issues/%d/timeline", owner, repo, prNumber)
-
-	err := paginate(ctx, c, path, func(item *githubTimelineEvent) error {
+	collect := func(item *githubTimelineEvent) error {
 		if event := c.parseTimelineEvent(ctx, owner, repo, item); event != nil {
-			events = append(events, *event)
+			events = appendEvent(ctx, events, *event)
 		}
 		return nil
-	})
+	}
+
+	accept := defaultAccept
+	err := paginate(ctx, c, path, collect)
+	if isUnsupportedMediaType(err) {
+		// Some GitHub Enterprise versions still gate the timeline behind a preview media type.
+		c.logger.InfoContext(ctx, "timeline rejected default media type, retrying with preview", "accept", timelinePreviewAccept)
+		accept = timelinePreviewAccept
+		events = nil
+		err = paginate(withAccept(ctx, accept), c, path, collect)
+	}
 
 	if err != nil {
-		return nil, fmt.Errorf("fetching timeline events: %w", err)
+		return partial(events, fmt.Errorf("fetching timeline events: %w", err))
 	}
 
-	c.logger.DebugContext(ctx, "fetched timeline events", "count", len(events))
+	c.logger.DebugContext(ctx, "fetched timeline events", "count", len(events), "accept", accept)
 	return events, nil
 }
 
 func (c *Client) parseTimelineEvent(ctx context.Context, owner, repo string, item *githubTimelineEvent) *Event {
 	event := &Event{
 		Kind:      item.Event,
-		Timestamp: item.CreatedAt,
+		Timestamp: item.CreatedAt.Time,
 	}
 
 	// Handle actor
 	if item.Actor != nil {
 		event.Actor = item.Actor.Login
-		event.Bot = isBot(item.Actor)
+		event.Bot = c.isBot(item.Actor)
 		if item.AuthorAssociation != "" {
 			event.WriteAccess = c.writeAccess(ctx, owner, repo, item.Actor, item.AuthorAssociation)
 		}
@@ -202,28 +314,77 @@ func (c *Client) parseTimelineEvent(ctx context.Context, owner, repo string, ite
 			return nil
 		}
 		event.Target = item.Assignee.Login
-		event.TargetIsBot = isBot(item.Assignee)
+		event.TargetIsBot = c.isBot(item.Assignee)
 	case "labeled", "unlabeled":
 		if item.Label.Name == "" {
 			return nil
 		}
 		event.Target = item.Label.Name
+		event.LabelColor = item.Label.Color
+		event.LabelDescription = item.Label.Description
 	case "milestoned", "demilestoned":
 		if item.Milestone.Title == "" {
 			return nil
 		}
 		event.Target = item.Milestone.Title
 	case "review_requested", "review_request_removed":
+		// GitHub records team and individual requests as separate timeline items.
+		// If a malformed payload carries both, the individual reviewer takes
+		// precedence so the result is deterministic.
+		if item.RequestedReviewer != nil && item.RequestedTeam.Name != "" {
+			c.logger.DebugContext(ctx, "review request names both a reviewer and a team; keeping the reviewer",
+				"reviewer", item.RequestedReviewer.Login, "team", item.RequestedTeam.Name)
+		}
 		if item.RequestedReviewer != nil {
 			event.Target = item.RequestedReviewer.Login
-			event.TargetIsBot = isBot(item.RequestedReviewer)
+			event.TargetIsBot = c.isBot(item.RequestedReviewer)
 		} else if item.RequestedTeam.Name != "" {
 			event.Target = item.RequestedTeam.Name
 		} else {
 			return nil
 		}
+	case EventKindReadyForReview, EventKindConvertToDraft:
+		// Kind and actor are all GitHub reports: who moved the PR out of or into draft.
 	case "mentioned":
 		event.Body = "User was mentioned"
+	case EventKindHeadRefForcePushed:
+		// The commit the branch was force-pushed to; the previous head is not reported.
+		event.SHA = item.CommitID
+	case EventKindRenamed:
+		if item.Rename.From == "" && item.Rename.To == "" {
+			return nil
+		}
+		event.Body = item.Rename.To
+		event.RenamedFrom = item.Rename.From
+	case EventKindReviewDismissed:
+		// The actor dismissed someone else's review; record what it was and why.
+		event.Outcome = reviewOutcome(item.DismissedReview.State)
+		event.Body = c.truncateBody(item.DismissedReview.DismissalMessage)
+		event.ReviewID = item.DismissedReview.ReviewID
+	case EventKindCrossReferenced:
+		// Another issue or pull request mentioned this one; target it as owner/repo#number.
+		source := item.Source.Issue
+		if source == nil || source.Number == 0 {
+			return nil
+		}
+		sourceRepo := owner + "/" + repo
+		if source.Repository != nil && source.Repository.FullName != "" {
+			sourceRepo = source.Repository.FullName
+		}
+		event.Target = fmt.Sprintf("%s#%d", sourceRepo, source.Number)
+	case EventKindReferenced:
+		// A commit mentioned this pull request.
+		if item.CommitID == "" {
+			return nil
+		}
+		event.Target = item.CommitID
+		event.SHA = item.CommitID
+	case EventKindAutoMergeEnabled, EventKindAutoMergeDisabled:
+		// The actor is who toggled auto-merge; keep the requested method when GitHub sends it.
+		event.Body = item.AutoMerge.MergeMethod
+	case EventKindMerged, EventKindClosed:
+		// Empty when a PR is closed without a commit.
+		event.SHA = item.CommitID
 	}
 
 	return event
@@ -239,32 +400,123 @@ func (c *Client) statusChecks(ctx context.Context, owner, repo string, pr *githu
 		return events, nil
 	}
 
-	path := fmt.Sprintf("/repos/%s/%s/statuses/%s?per_page=%d", owner, repo, pr.Head.SHA, maxPerPage)
+	// A fork's head commit is still queried on the base repository: that is where
+	// GitHub records the CI results for the pull request.
+	if pr.fromFork() {
+		c.logger.DebugContext(ctx, "head commit is from a fork", "head_repo", pr.Head.Repo.FullName)
+	}
+
+	if c.combinedStatus {
+		return c.combinedStatusChecks(ctx, owner, repo, pr.Head.SHA)
+	}
+
 	var statuses []*githubStatus
-	if _, err := c.github.get(ctx, path, &statuses); err != nil {
-		return nil, fmt.Errorf("fetching status checks: %w", err)
+	path := fmt.Sprintf("/repos/%s/%s/statuses/%s", owner, repo, pr.Head.SHA)
+	err := paginate(ctx, c, path, func(status *githubStatus) error {
+		// Updates to a context can be on any page, so the latest are only known at the end.
+		if c.latestStatuses {
+			statuses = append(statuses, status)
+			return nil
+		}
+		events = appendEvent(ctx, events, c.statusEvent(status))
+		return nil
+	})
+	for _, status := range latestStatuses(statuses) {
+		events = appendEvent(ctx, events, c.statusEvent(status))
+	}
+	if err != nil {
+		return partial(events, fmt.Errorf("fetching status checks: %w", err))
 	}
 
-	for _, status := range statuses {
-		event := Event{
-			Kind:      "status_check",
-			Timestamp: status.CreatedAt,
-			Outcome:   status.State,   // "success", "failure", "pending", "error"
-			Body:      status.Context, // The status check name
+	c.logger.DebugContext(ctx, "fetched status checks", "count", len(events))
+	return events, nil
+}
+
+// combinedStatusChecks fetches the combined status of a commit: the latest status for each
+// context, followed by an event for the overall state.
+func (c *Client) combinedStatusChecks(ctx context.Context, owner, repo, sha string) ([]Event, error) {
+	var events []Event
+	var all githubCombinedStatus
+	path := fmt.Sprintf("/repos/%s/%s/commits/%s/status", owner, repo, sha)
+	err := followPages(ctx, c, path, func(pagePath string) (*githubResponse, error) {
+		var combined githubCombinedStatus
+		resp, err := c.github.get(ctx, pagePath, &combined)
+		if err != nil {
+			return nil, err
 		}
-		if status.Creator != nil {
-			event.Actor = status.Creator.Login
-			event.Bot = isBot(status.Creator)
-		} else {
-			event.Actor = "unknown"
+		all.State = combined.State
+		for _, status := range combined.Statuses {
+			all.Statuses = append(all.Statuses, status)
+			events = appendEvent(ctx, events, c.statusEvent(status))
 		}
-		events = append(events, event)
+		return resp, nil
+	})
+	if err != nil {
+		return partial(events, fmt.Errorf("fetching combined status: %w", err))
 	}
 
-	c.logger.DebugContext(ctx, "fetched status checks", "count", len(events))
+	// A commit with no statuses has nothing to summarize; GitHub still reports it as "pending".
+	if len(all.Statuses) > 0 {
+		events = appendEvent(ctx, events, combinedStatusEvent(&all))
+	}
+
+	c.logger.DebugContext(ctx, "fetched combined status", "state", all.State, "count", len(events))
 	return events, nil
 }
 
+// combinedStatusEvent converts the overall state of a commit's statuses into an event,
+// stamped with the time of the latest status.
+func combinedStatusEvent(combined *githubCombinedStatus) Event {
+	var latest time.Time
+	for _, status := range combined.Statuses {
+		if status.CreatedAt.Time.After(latest) {
+			latest = status.CreatedAt.Time
+		}
+	}
+	return Event{
+		Kind:      EventKindCombinedStatus,
+		Timestamp: latest,
+		Actor:     "unknown",
+		Outcome:   combined.State, // "success", "failure", "pending", "error"
+	}
+}
+
+// statusEvent converts a commit status into an event.
+func (c *Client) statusEvent(status *githubStatus) Event {
+	event := Event{
+		Kind:      "status_check",
+		Timestamp: status.CreatedAt.Time,
+		Outcome:   status.State,   // "success", "failure", "pending", "error"
+		Body:      status.Context, // The status check name
+		URL:       status.TargetURL,
+	}
+	if status.Creator != nil {
+		event.Actor = status.Creator.Login
+		event.Bot = c.isBot(status.Creator)
+	} else {
+		event.Actor = "unknown"
+	}
+	return event
+}
+
+// latestStatuses keeps only the most recent update of each status context, as GitHub's
+// combined status does, in the order the kept statuses were given.
+func latestStatuses(statuses []*githubStatus) []*githubStatus {
+	latest := make(map[string]*githubStatus, len(statuses))
+	for _, status := range statuses {
+		if prev, ok := latest[status.Context]; !ok || status.CreatedAt.Time.After(prev.CreatedAt.Time) {
+			latest[status.Context] = status
+		}
+	}
+	kept := make([]*githubStatus, 0, len(latest))
+	for _, status := range statuses {
+		if latest[status.Context] == status {
+			kept = append(kept, status)
+		}
+	}
+	return kept
+}
+
 func (c *Client) checkRuns(ctx context.Context, owner, repo string, pr *githubPullRequest) ([]Event, error) {
 	c.logger.DebugContext(ctx, "fetching check runs", "owner", owner, "repo", repo, "sha", pr.Head.SHA)
 
@@ -275,37 +527,95 @@ func (c *Client) checkRuns(ctx context.Context, owner, repo string, pr *githubPu
 		return events, nil
 	}
 
-	path := fmt.Sprintf("/repos/%s/%s/commits/%s/check-runs?per_page=%d", owner, repo, pr.Head.SHA, maxPerPage)
-	var checkRuns githubCheckRuns
-	if _, err := c.github.get(ctx, path, &checkRuns); err != nil {
-		return nil, fmt.Errorf("fetching check runs: %w", err)
+	// A fork's head commit is still queried on the base repository: that is where
+	// GitHub records the CI results for the pull request.
+	if pr.fromFork() {
+		c.logger.DebugContext(ctx, "head commit is from a fork", "head_repo", pr.Head.Repo.FullName)
 	}
 
-	for _, checkRun := range checkRuns.CheckRuns {
-		timestamp := checkRun.StartedAt
-		if !checkRun.CompletedAt.IsZero() {
-			timestamp = checkRun.CompletedAt
+	var runs []*githubCheckRun
+	err := c.eachCheckRun(ctx, owner, repo, pr.Head.SHA, func(checkRun *githubCheckRun) {
+		// Re-runs can be on any page, so the latest attempts are only known at the end.
+		if c.latestCheckRuns {
+			runs = append(runs, checkRun)
+			return
 		}
+		events = appendEvent(ctx, events, checkRunEvent(checkRun))
+	})
+	for _, checkRun := range latestCheckRuns(runs) {
+		events = appendEvent(ctx, events, checkRunEvent(checkRun))
+	}
+	if err != nil {
+		return partial(events, fmt.Errorf("fetching check runs: %w", err))
+	}
 
-		var actor string
-		if checkRun.App.Owner != nil {
-			actor = checkRun.App.Owner.Login
-		}
+	c.logger.DebugContext(ctx, "fetched check runs", "count", len(events))
+	return events, nil
+}
 
-		event := Event{
-			Kind:      "check_run",
-			Timestamp: timestamp,
-			Actor:     actor,
-			Outcome:   checkRun.Conclusion, // "success", "failure", "neutral", "cancelled", "skipped", "timed_out", "action_required"
-			Body:      checkRun.Name,       // Store check run name in body field
+// eachCheckRun calls fn for every check run on the commit sha, page by page.
+func (c *Client) eachCheckRun(ctx context.Context, owner, repo, sha string, fn func(*githubCheckRun)) error {
+	path := fmt.Sprintf("/repos/%s/%s/commits/%s/check-runs", owner, repo, sha)
+	return followPages(ctx, c, path, func(pagePath string) (*githubResponse, error) {
+		var checkRuns githubCheckRuns
+		resp, err := c.github.get(ctx, pagePath, &checkRuns)
+		if err != nil {
+			return nil, err
 		}
-		// GitHub Apps are always considered bots
-		if checkRun.App.Owner != nil {
-			event.Bot = true
+		for _, checkRun := range checkRuns.CheckRuns {
+			fn(checkRun)
 		}
-		events = append(events, event)
+		return resp, nil
+	})
+}
+
+// checkRunEvent converts a check run into an event.
+func checkRunEvent(checkRun *githubCheckRun) Event {
+	timestamp := checkRun.StartedAt.Time
+	if !checkRun.CompletedAt.Time.IsZero() {
+		timestamp = checkRun.CompletedAt.Time
 	}
 
-	c.logger.DebugContext(ctx, "fetched check runs", "count", len(events))
-	return events, nil
+	var actor string
+	if checkRun.App.Owner != nil {
+		actor = checkRun.App.Owner.Login
+	}
+
+	event := Event{
+		Kind:         "check_run",
+		Timestamp:    timestamp,
+		Actor:        actor,
+		Outcome:      checkRun.Conclusion, // "success", "failure", "neutral", "cancelled", "skipped", "timed_out", "action_required"
+		Body:         checkRun.Name,       // Store check run name in body field
+		URL:          checkRun.url(),
+		ExternalID:   checkRun.ExternalID,
+		CheckSuiteID: checkRun.CheckSuite.ID,
+		Elapsed:      checkRun.elapsed(),
+	}
+	// GitHub Apps are always considered bots
+	if checkRun.App.Owner != nil {
+		event.Bot = true
+	}
+	return event
+}
+
+// latestCheckRuns keeps only the most recent attempt of each check, by name, in the
+// order the kept runs were given. An attempt that started later wins; between runs
+// that started together, the one that completed later does.
+func latestCheckRuns(runs []*githubCheckRun) []*githubCheckRun {
+	latest := make(map[string]*githubCheckRun, len(runs))
+	for _, run := range runs {
+		prev, ok := latest[run.Name]
+		if !ok || run.StartedAt.Time.After(prev.StartedAt.Time) ||
+			(run.StartedAt.Time.Equal(prev.StartedAt.Time) && run.CompletedAt.Time.After(prev.CompletedAt.Time)) {
+			latest[run.Name] = run
+		}
+	}
+	kept := make([]*githubCheckRun, 0, len(latest))
+	for _, run := range runs {
+		if latest[run.Name] == run {
+			kept = append(kept, run)
+		}
+	}
+	return kept
 }
@@ -2,7 +2,14 @@ package prx
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 )
@@ -42,6 +49,21 @@ func TestIsBot(t *testing.T) {
 			},
 			expected: true,
 		},
+		{
+			name:     "dependabot without type",
+			user:     &githubUser{Login: "dependabot[bot]"},
+			expected: true,
+		},
+		{
+			name:     "github-actions without type",
+			user:     &githubUser{Login: "github-actions[bot]"},
+			expected: true,
+		},
+		{
+			name:     "bot in the middle of a login",
+			user:     &githubUser{Login: "bot[bot]ler", Type: "User"},
+			expected: false,
+		},
 		{
 			name: "regular user",
 			user: &githubUser{
@@ -90,7 +112,7 @@ func TestParseTimelineEvent_Targets(t *testing.T) {
 			name: "assigned event with target",
 			event: &githubTimelineEvent{
 				Event:     "assigned",
-				CreatedAt: time.Now(),
+				CreatedAt: githubTime{Time: time.Now()},
 				Actor:     &githubUser{Login: "manager"},
 				Assignee:  &githubUser{Login: "developer1"},
 			},
@@ -100,7 +122,7 @@ func TestParseTimelineEvent_Targets(t *testing.T) {
 			name: "review_requested event with user target",
 			event: &githubTimelineEvent{
 				Event:             "review_requested",
-				CreatedAt:         time.Now(),
+				CreatedAt:         githubTime{Time: time.Now()},
 				Actor:             &githubUser{Login: "author"},
 				RequestedReviewer: &githubUser{Login: "reviewer1"},
 			},
@@ -110,7 +132,7 @@ func TestParseTimelineEvent_Targets(t *testing.T) {
 			name: "review_requested event with team target",
 			event: &githubTimelineEvent{
 				Event:     "review_requested",
-				CreatedAt: time.Now(),
+				CreatedAt: githubTime{Time: time.Now()},
 				Actor:     &githubUser{Login: "author"},
 				RequestedTeam: struct {
 					Name string `json:"name"`
@@ -122,11 +144,9 @@ func TestParseTimelineEvent_Targets(t *testing.T) {
 			name: "labeled event with target",
 			event: &githubTimelineEvent{
 				Event:     "labeled",
-				CreatedAt: time.Now(),
+				CreatedAt: githubTime{Time: time.Now()},
 				Actor:     &githubUser{Login: "triager"},
-				Label: struct {
-					Name string `json:"name"`
-				}{Name: "bug"},
+				Label:     githubLabel{Name: "bug"},
 			},
 			expectedTargets: []string{"bug"},
 		},
@@ -134,7 +154,7 @@ func TestParseTimelineEvent_Targets(t *testing.T) {
 			name: "milestoned event with target",
 			event: &githubTimelineEvent{
 				Event:     "milestoned",
-				CreatedAt: time.Now(),
+				CreatedAt: githubTime{Time: time.Now()},
 				Actor:     &githubUser{Login: "pm"},
 				Milestone: struct {
 					Title string `json:"title"`
@@ -161,3 +181,1048 @@ func TestParseTimelineEvent_Targets(t *testing.T) {
 		})
 	}
 }
+
+func TestParseTimelineEvent_LabelDetails(t *testing.T) {
+	c := &Client{logger: slog.Default()}
+	tests := []struct {
+		name            string
+		label           githubLabel
+		wantColor       string
+		wantDescription string
+	}{
+		{
+			name:            "with description",
+			label:           githubLabel{Name: "bug", Color: "d73a4a", Description: "Something isn't working"},
+			wantColor:       "d73a4a",
+			wantDescription: "Something isn't working",
+		},
+		{
+			name:      "without description",
+			label:     githubLabel{Name: "wip", Color: "ededed"},
+			wantColor: "ededed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := &githubTimelineEvent{
+				Event:     "labeled",
+				CreatedAt: githubTime{Time: time.Now()},
+				Actor:     &githubUser{Login: "triager"},
+				Label:     tt.label,
+			}
+			event := c.parseTimelineEvent(context.Background(), "owner", "repo", item)
+			if event == nil {
+				t.Fatal("expected an event")
+			}
+			if event.Target != tt.label.Name {
+				t.Errorf("Target = %q, want %q", event.Target, tt.label.Name)
+			}
+			if event.LabelColor != tt.wantColor || event.LabelDescription != tt.wantDescription {
+				t.Errorf("label color/description = %q/%q, want %q/%q",
+					event.LabelColor, event.LabelDescription, tt.wantColor, tt.wantDescription)
+			}
+		})
+	}
+}
+
+func TestReviewCommentsReviewID(t *testing.T) {
+	now := time.Now()
+	mock := &mockGithubClient{
+		responses: map[string]any{
+			"/repos/owner/repo/pulls/1/reviews?page=1&per_page=100": []*githubReview{
+				{ID: 10, User: &githubUser{Login: "reviewer"}, SubmittedAt: githubTime{Time: now}, State: "COMMENTED"},
+			},
+			"/repos/owner/repo/pulls/1/comments?page=1&per_page=100": []*githubReviewComment{
+				{User: &githubUser{Login: "reviewer"}, CreatedAt: githubTime{Time: now}, Body: "nit", PullRequestReviewID: 10},
+				{User: &githubUser{Login: "reviewer"}, CreatedAt: githubTime{Time: now}, Body: "typo", PullRequestReviewID: 10},
+				{User: &githubUser{Login: "other"}, CreatedAt: githubTime{Time: now}, Body: "hmm", PullRequestReviewID: 11},
+			},
+		},
+	}
+	c := &Client{github: mock, logger: slog.Default()}
+	ctx := context.Background()
+
+	reviews, err := c.reviews(ctx, "owner", "repo", 1)
+	if err != nil {
+		t.Fatalf("reviews() error = %v", err)
+	}
+	comments, err := c.reviewComments(ctx, "owner", "repo", 1)
+	if err != nil {
+		t.Fatalf("reviewComments() error = %v", err)
+	}
+
+	if len(reviews) != 1 || reviews[0].ReviewID != 10 {
+		t.Fatalf("expected one review with ReviewID 10, got %+v", reviews)
+	}
+	if len(comments) != 3 {
+		t.Fatalf("expected 3 review comments, got %d", len(comments))
+	}
+	if comments[0].ReviewID != reviews[0].ReviewID || comments[1].ReviewID != reviews[0].ReviewID {
+		t.Errorf("comments from the same review should share its ID: got %d and %d, want %d",
+			comments[0].ReviewID, comments[1].ReviewID, reviews[0].ReviewID)
+	}
+	if comments[2].ReviewID != 11 {
+		t.Errorf("expected ReviewID 11 on comment from a different review, got %d", comments[2].ReviewID)
+	}
+}
+
+func TestReviewCommentsPathAndLine(t *testing.T) {
+	payload := `[
+		{"user":{"login":"reviewer"},"body":"off by one","path":"pkg/prx/utils.go","line":42,"original_line":40},
+		{"user":{"login":"reviewer"},"body":"stale","path":"README.md","line":null,"original_line":7}
+	]`
+	var comments []*githubReviewComment
+	if err := json.Unmarshal([]byte(payload), &comments); err != nil {
+		t.Fatal(err)
+	}
+	mock := &mockGithubClient{
+		responses: map[string]any{"/repos/owner/repo/pulls/1/comments?page=1&per_page=100": comments},
+	}
+	c := &Client{github: mock, logger: slog.Default()}
+
+	events, err := c.reviewComments(context.Background(), "owner", "repo", 1)
+	if err != nil {
+		t.Fatalf("reviewComments() error = %v", err)
+	}
+	type location struct {
+		path           string
+		line, original int
+	}
+	want := []location{{"pkg/prx/utils.go", 42, 40}, {"README.md", 0, 7}}
+	if len(events) != len(want) {
+		t.Fatalf("got %d events, want %d", len(events), len(want))
+	}
+	for i, e := range events {
+		if got := (location{e.Path, e.Line, e.OriginalLine}); got != want[i] {
+			t.Errorf("event %d location = %+v, want %+v", i, got, want[i])
+		}
+	}
+}
+
+func TestReviewCommentsThreads(t *testing.T) {
+	payload := `[
+		{"id":100,"user":{"login":"reviewer"},"body":"rename this?"},
+		{"id":101,"in_reply_to_id":100,"user":{"login":"author"},"body":"done"},
+		{"id":102,"in_reply_to_id":100,"user":{"login":"reviewer"},"body":"thanks"}
+	]`
+	var comments []*githubReviewComment
+	if err := json.Unmarshal([]byte(payload), &comments); err != nil {
+		t.Fatal(err)
+	}
+	mock := &mockGithubClient{
+		responses: map[string]any{"/repos/owner/repo/pulls/1/comments?page=1&per_page=100": comments},
+	}
+	c := &Client{github: mock, logger: slog.Default()}
+
+	events, err := c.reviewComments(context.Background(), "owner", "repo", 1)
+	if err != nil {
+		t.Fatalf("reviewComments() error = %v", err)
+	}
+	var got [][2]int64
+	for _, e := range events {
+		got = append(got, [2]int64{e.CommentID, e.InReplyTo})
+	}
+	want := [][2]int64{{100, 0}, {101, 100}, {102, 100}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("(comment, in reply to) = %v, want %v", got, want)
+	}
+}
+
+func TestReviewCommentsOutdated(t *testing.T) {
+	payload := `[
+		{"user":{"login":"reviewer"},"body":"current","position":3,"line":12},
+		{"user":{"login":"reviewer"},"body":"stale","position":null,"line":null,"original_line":9}
+	]`
+	var comments []*githubReviewComment
+	if err := json.Unmarshal([]byte(payload), &comments); err != nil {
+		t.Fatal(err)
+	}
+	mock := &mockGithubClient{
+		responses: map[string]any{"/repos/owner/repo/pulls/1/comments?page=1&per_page=100": comments},
+	}
+	c := &Client{github: mock, logger: slog.Default()}
+
+	events, err := c.reviewComments(context.Background(), "owner", "repo", 1)
+	if err != nil {
+		t.Fatalf("reviewComments() error = %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("got %d events, want 2", len(events))
+	}
+	if events[0].Outdated {
+		t.Error("comment with a position should not be outdated")
+	}
+	if !events[1].Outdated {
+		t.Error("comment with a null position should be outdated")
+	}
+}
+
+func TestReviewCommentsDiffHunk(t *testing.T) {
+	hunk := "@@ -10,6 +10,8 @@ func main() {\n \tfmt.Println(\"hi\")\n+\tos.Exit(1)"
+	long := "@@ -1,1 +1,1 @@\n" + strings.Repeat("+x\n", maxDiffHunkLength)
+	mock := &mockGithubClient{
+		responses: map[string]any{
+			"/repos/owner/repo/pulls/1/comments?page=1&per_page=100": []*githubReviewComment{
+				{User: &githubUser{Login: "reviewer"}, Body: "why exit?", DiffHunk: hunk},
+				{User: &githubUser{Login: "reviewer"}, Body: "big", DiffHunk: long},
+			},
+		},
+	}
+	ctx := context.Background()
+
+	c := &Client{github: mock, logger: slog.Default()}
+	comments, err := c.reviewComments(ctx, "owner", "repo", 1)
+	if err != nil {
+		t.Fatalf("reviewComments() error = %v", err)
+	}
+	for _, e := range comments {
+		if e.DiffHunk != "" {
+			t.Errorf("DiffHunk should be empty without WithDiffHunks, got %q", e.DiffHunk)
+		}
+	}
+
+	c = &Client{github: mock, logger: slog.Default()}
+	WithDiffHunks()(c)
+	comments, err = c.reviewComments(ctx, "owner", "repo", 1)
+	if err != nil {
+		t.Fatalf("reviewComments() error = %v", err)
+	}
+	if len(comments) != 2 {
+		t.Fatalf("expected 2 review comments, got %d", len(comments))
+	}
+	if comments[0].DiffHunk != hunk {
+		t.Errorf("DiffHunk = %q, want %q", comments[0].DiffHunk, hunk)
+	}
+	if len(comments[1].DiffHunk) != maxDiffHunkLength {
+		t.Errorf("long DiffHunk length = %d, want capped at %d", len(comments[1].DiffHunk), maxDiffHunkLength)
+	}
+}
+
+func TestParseTimelineEvent_ReviewRequestPrecedence(t *testing.T) {
+	c := &Client{logger: slog.Default()}
+	item := &githubTimelineEvent{
+		Event:             "review_requested",
+		CreatedAt:         githubTime{Time: time.Now()},
+		Actor:             &githubUser{Login: "author"},
+		RequestedReviewer: &githubUser{Login: "reviewer1"},
+		RequestedTeam: struct {
+			Name string `json:"name"`
+		}{Name: "backend-team"},
+	}
+
+	event := c.parseTimelineEvent(context.Background(), "owner", "repo", item)
+	if event == nil {
+		t.Fatal("expected non-nil event")
+	}
+	if event.Target != "reviewer1" {
+		t.Errorf("expected the individual reviewer to take precedence, got target %q", event.Target)
+	}
+
+	item.RequestedReviewer = nil
+	item.RequestedTeam.Name = ""
+	if event := c.parseTimelineEvent(context.Background(), "owner", "repo", item); event != nil {
+		t.Errorf("expected nil event when neither reviewer nor team is set, got %+v", event)
+	}
+}
+
+func TestCheckRunsDetails(t *testing.T) {
+	mock := &mockGithubClient{
+		responses: map[string]any{
+			"/repos/owner/repo/commits/abc123/check-runs?page=1&per_page=100": githubCheckRuns{CheckRuns: []*githubCheckRun{
+				{
+					Name:        "build",
+					Conclusion:  "success",
+					CompletedAt: githubTime{Time: time.Now()},
+					DetailsURL:  "https://ci.example.com/runs/42",
+					ExternalID:  "run-42",
+				},
+				{
+					Name:        "lint",
+					Conclusion:  "failure",
+					CompletedAt: githubTime{Time: time.Now()},
+					HTMLURL:     "https://github.com/owner/repo/runs/7",
+				},
+			}},
+		},
+	}
+	c := &Client{github: mock, logger: slog.Default()}
+
+	pr := &githubPullRequest{}
+	pr.Head.SHA = "abc123"
+	events, err := c.checkRuns(context.Background(), "owner", "repo", pr)
+	if err != nil {
+		t.Fatalf("checkRuns() error = %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("expected 2 check run events, got %d", len(events))
+	}
+	if events[0].URL != "https://ci.example.com/runs/42" {
+		t.Errorf("URL = %q, want details URL", events[0].URL)
+	}
+	if events[0].ExternalID != "run-42" {
+		t.Errorf("ExternalID = %q, want %q", events[0].ExternalID, "run-42")
+	}
+	if events[1].URL != "https://github.com/owner/repo/runs/7" {
+		t.Errorf("URL = %q, want HTML URL when there is no details URL", events[1].URL)
+	}
+}
+
+func TestStatusChecksURL(t *testing.T) {
+	mock := &mockGithubClient{
+		responses: map[string]any{
+			"/repos/owner/repo/statuses/abc123?page=1&per_page=100": []*githubStatus{
+				{Context: "ci/build", State: "failure", Creator: &githubUser{Login: "ci"}, TargetURL: "https://ci.example.com/builds/9"},
+			},
+		},
+	}
+	c := NewClient("token")
+	c.github = mock
+
+	events, err := c.statusChecks(context.Background(), "owner", "repo", &githubPullRequest{Head: githubRef{SHA: "abc123"}})
+	if err != nil {
+		t.Fatalf("statusChecks() error = %v", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("expected 1 status event, got %d", len(events))
+	}
+	if events[0].URL != "https://ci.example.com/builds/9" {
+		t.Errorf("URL = %q, want target URL", events[0].URL)
+	}
+}
+
+func TestCommitsVerification(t *testing.T) {
+	payload := `[
+		{"sha":"aaa111","author":{"login":"dev"},"commit":{"message":"signed","verification":{"verified":true,"reason":"valid"}}},
+		{"sha":"bbb222","author":{"login":"dev"},"commit":{"message":"unsigned","verification":{"verified":false,"reason":"unsigned"}}}
+	]`
+	var commits []*githubPullRequestCommit
+	if err := json.Unmarshal([]byte(payload), &commits); err != nil {
+		t.Fatal(err)
+	}
+	mock := &mockGithubClient{
+		responses: map[string]any{"/repos/owner/repo/pulls/1/commits?page=1&per_page=100": commits},
+	}
+	c := &Client{github: mock, logger: slog.Default(), permissionCache: &permissionCache{memory: make(map[string]permissionEntry)}}
+
+	events, err := c.commits(context.Background(), "owner", "repo", 1)
+	if err != nil {
+		t.Fatalf("commits() error = %v", err)
+	}
+	type commit struct {
+		sha      string
+		verified bool
+	}
+	var got []commit
+	for _, e := range events {
+		got = append(got, commit{e.SHA, e.Verified})
+	}
+	want := []commit{{"aaa111", true}, {"bbb222", false}}
+	if !slices.Equal(got, want) {
+		t.Errorf("commits = %+v, want %+v", got, want)
+	}
+}
+
+func TestCommitsWriteAccess(t *testing.T) {
+	collaborator := &githubPullRequestCommit{Author: &githubUser{Login: "collab"}}
+	collaborator.Commit.Message = "Fix bug"
+	unlinked := &githubPullRequestCommit{} // author email not linked to an account
+	unlinked.Commit.Message = "Update docs"
+
+	mock := &mockGithubClient{
+		responses: map[string]any{
+			"/repos/owner/repo/pulls/1/commits?page=1&per_page=100": []*githubPullRequestCommit{collaborator, unlinked},
+			"/repos/owner/repo/collaborators/collab/permission":     "write",
+		},
+	}
+	c := &Client{
+		github:          mock,
+		logger:          slog.Default(),
+		permissionCache: &permissionCache{memory: make(map[string]permissionEntry)},
+	}
+
+	events, err := c.commits(context.Background(), "owner", "repo", 1)
+	if err != nil {
+		t.Fatalf("commits() error = %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("expected 2 commit events, got %d", len(events))
+	}
+	if events[0].WriteAccess != WriteAccessDefinitely {
+		t.Errorf("collaborator commit WriteAccess = %d, want %d", events[0].WriteAccess, WriteAccessDefinitely)
+	}
+	if events[1].WriteAccess != WriteAccessNA {
+		t.Errorf("unlinked commit WriteAccess = %d, want %d", events[1].WriteAccess, WriteAccessNA)
+	}
+
+	var permissionCalls int
+	for _, call := range mock.calls {
+		if strings.HasSuffix(call, "/permission") {
+			permissionCalls++
+		}
+	}
+	if permissionCalls != 1 {
+		t.Errorf("expected 1 permission lookup, got %d", permissionCalls)
+	}
+}
+
+func TestTimelineEventsPreviewFallback(t *testing.T) {
+	var accepts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		accept := r.Header.Get("Accept")
+		accepts = append(accepts, accept)
+		if accept != timelinePreviewAccept {
+			http.Error(w, `{"message":"Unsupported Media Type"}`, http.StatusUnsupportedMediaType)
+			return
+		}
+		if _, err := w.Write([]byte(`[{"event":"labeled","actor":{"login":"triager"},"label":{"name":"bug"}}]`)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	events, err := client.timelineEvents(context.Background(), "owner", "repo", 1)
+	if err != nil {
+		t.Fatalf("timelineEvents() error = %v", err)
+	}
+	if len(events) != 1 || events[0].Kind != "labeled" {
+		t.Errorf("expected one labeled event, got %+v", events)
+	}
+	want := []string{defaultAccept, timelinePreviewAccept}
+	if !slices.Equal(accepts, want) {
+		t.Errorf("Accept headers = %q, want %q", accepts, want)
+	}
+}
+
+func TestCommentsRenderedBodies(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := `[{"user":{"login":"dev"},"body":"**hi**"}]`
+		if r.Header.Get("Accept") == fullAccept {
+			body = `[{"user":{"login":"dev"},"body":"**hi**","body_html":"<p><strong>hi</strong></p>"}]`
+		}
+		if _, err := w.Write([]byte(body)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{name: "default", want: ""},
+		{name: "rendered", opts: []Option{WithRenderedBodies()}, want: "<p><strong>hi</strong></p>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, server, tt.opts...)
+			events, err := client.comments(context.Background(), "owner", "repo", 1)
+			if err != nil {
+				t.Fatalf("comments() error = %v", err)
+			}
+			if len(events) != 1 {
+				t.Fatalf("expected 1 comment, got %d", len(events))
+			}
+			if events[0].Body != "**hi**" {
+				t.Errorf("Body = %q, want raw markdown", events[0].Body)
+			}
+			if events[0].BodyHTML != tt.want {
+				t.Errorf("BodyHTML = %q, want %q", events[0].BodyHTML, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatusChecksPagination(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := `[{"state":"success","context":"ci/lint","creator":{"login":"ci"}}]`
+		if r.URL.Query().Get("page") == "1" {
+			w.Header().Set("Link", `<`+r.URL.Path+`?page=2&per_page=100>; rel="next"`)
+			body = `[{"state":"failure","context":"ci/test","creator":{"login":"ci"}}]`
+		}
+		if _, err := w.Write([]byte(body)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	pr := &githubPullRequest{Head: githubRef{SHA: "abc123"}}
+	events, err := newTestClient(t, server).statusChecks(context.Background(), "owner", "repo", pr)
+	if err != nil {
+		t.Fatalf("statusChecks() error = %v", err)
+	}
+	var got []string
+	for _, e := range events {
+		got = append(got, e.Body)
+	}
+	if want := []string{"ci/test", "ci/lint"}; !slices.Equal(got, want) {
+		t.Errorf("status checks = %q, want %q", got, want)
+	}
+}
+
+func TestCheckRunsPagination(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := `{"total_count":2,"check_runs":[{"name":"build","conclusion":"success"}]}`
+		if r.URL.Query().Get("page") == "1" {
+			w.Header().Set("Link", `<`+r.URL.Path+`?page=2&per_page=100>; rel="next"`)
+			body = `{"total_count":2,"check_runs":[{"name":"test","conclusion":"failure"}]}`
+		}
+		if _, err := w.Write([]byte(body)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	pr := &githubPullRequest{Head: githubRef{SHA: "abc123"}}
+	events, err := newTestClient(t, server).checkRuns(context.Background(), "owner", "repo", pr)
+	if err != nil {
+		t.Fatalf("checkRuns() error = %v", err)
+	}
+	var got []string
+	for _, e := range events {
+		got = append(got, e.Body)
+	}
+	if want := []string{"test", "build"}; !slices.Equal(got, want) {
+		t.Errorf("check runs = %q, want %q", got, want)
+	}
+}
+
+func TestCheckRunsElapsed(t *testing.T) {
+	start := time.Date(2024, 2, 1, 10, 0, 0, 0, time.UTC)
+	mock := &mockGithubClient{
+		responses: map[string]any{
+			"/repos/owner/repo/commits/abc123/check-runs?page=1&per_page=100": githubCheckRuns{CheckRuns: []*githubCheckRun{
+				{Name: "build", Conclusion: "success", StartedAt: githubTime{Time: start}, CompletedAt: githubTime{Time: start.Add(4*time.Minute + 30*time.Second)}},
+				{Name: "test", Status: "in_progress", StartedAt: githubTime{Time: start}},
+				{Name: "queued", Status: "queued"},
+			}},
+		},
+	}
+	c := &Client{github: mock, logger: slog.Default()}
+
+	events, err := c.checkRuns(context.Background(), "owner", "repo", &githubPullRequest{Head: githubRef{SHA: "abc123"}})
+	if err != nil {
+		t.Fatalf("checkRuns() error = %v", err)
+	}
+	want := map[string]time.Duration{"build": 4*time.Minute + 30*time.Second, "test": 0, "queued": 0}
+	if len(events) != len(want) {
+		t.Fatalf("got %d events, want %d", len(events), len(want))
+	}
+	for _, e := range events {
+		if e.Elapsed != want[e.Body] {
+			t.Errorf("%s: Elapsed = %v, want %v", e.Body, e.Elapsed, want[e.Body])
+		}
+	}
+}
+
+func TestCheckRunsSuite(t *testing.T) {
+	mock := &mockGithubClient{
+		responses: map[string]any{
+			"/repos/owner/repo/commits/abc123/check-runs?page=1&per_page=100": json.RawMessage(`{"total_count": 3, "check_runs": [
+				{"name": "build", "conclusion": "success", "check_suite": {"id": 101}},
+				{"name": "test", "conclusion": "failure", "check_suite": {"id": 101}},
+				{"name": "deploy", "conclusion": "success", "check_suite": {"id": 202}}
+			]}`),
+		},
+	}
+	c := &Client{github: mock, logger: slog.Default()}
+
+	events, err := c.checkRuns(context.Background(), "owner", "repo", &githubPullRequest{Head: githubRef{SHA: "abc123"}})
+	if err != nil {
+		t.Fatalf("checkRuns() error = %v", err)
+	}
+	want := map[string]int64{"build": 101, "test": 101, "deploy": 202}
+	if len(events) != len(want) {
+		t.Fatalf("got %d events, want %d", len(events), len(want))
+	}
+	for _, e := range events {
+		if e.CheckSuiteID != want[e.Body] {
+			t.Errorf("%s: CheckSuiteID = %d, want %d", e.Body, e.CheckSuiteID, want[e.Body])
+		}
+	}
+}
+
+func TestPaginateExistingQuery(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "/repos/o/r/pulls/1/commits", want: "/repos/o/r/pulls/1/commits?page=1&per_page=100"},
+		{path: "/repos/o/r/issues/1/comments?since=2024-01-01T00:00:00Z", want: "/repos/o/r/issues/1/comments?since=2024-01-01T00:00:00Z&page=1&per_page=100"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			mock := &mockGithubClient{responses: map[string]any{}}
+			client := &Client{github: mock, logger: slog.Default()}
+			err := paginate(context.Background(), client, tt.path, func(*githubComment) error { return nil })
+			if err != nil {
+				t.Fatalf("paginate() error = %v", err)
+			}
+			if len(mock.calls) != 1 || mock.calls[0] != tt.want {
+				t.Errorf("requested %q, want %q", mock.calls, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommentsBodyLimit(t *testing.T) {
+	long := strings.Repeat("word ", 100)
+	mock := &mockGithubClient{
+		responses: map[string]any{
+			"/repos/owner/repo/issues/1/comments?page=1&per_page=100": []*githubComment{
+				{User: &githubUser{Login: "dev"}, Body: long},
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		opts    []Option
+		wantLen int
+	}{
+		{name: "default", wantLen: defaultBodyLimit},
+		{name: "custom", opts: []Option{WithBodyLimit(20)}, wantLen: 20},
+		{name: "unlimited", opts: []Option{WithBodyLimit(0)}, wantLen: len(long)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewClient("token", tt.opts...)
+			client.github = mock
+			events, err := client.comments(context.Background(), "owner", "repo", 1)
+			if err != nil {
+				t.Fatalf("comments() error = %v", err)
+			}
+			if len(events) != 1 {
+				t.Fatalf("expected 1 comment, got %d", len(events))
+			}
+			body := events[0].Body
+			if len(body) != tt.wantLen {
+				t.Errorf("body length = %d, want %d", len(body), tt.wantLen)
+			}
+			if truncated := strings.HasSuffix(body, ellipsis); truncated != (tt.wantLen < len(long)) {
+				t.Errorf("body %q: ellipsis present = %v", body, truncated)
+			}
+		})
+	}
+}
+
+func TestTimelineEventKinds(t *testing.T) {
+	tests := []struct {
+		name     string
+		timeline string
+		want     []Event
+	}{
+		{
+			name: "head ref changes",
+			timeline: `[
+				{"event":"head_ref_force_pushed","actor":{"login":"author"},"created_at":"2024-01-01T10:00:00Z","commit_id":"def456"},
+				{"event":"head_ref_deleted","actor":{"login":"maintainer"},"created_at":"2024-01-02T10:00:00Z"},
+				{"event":"head_ref_restored","actor":{"login":"maintainer"},"created_at":"2024-01-03T10:00:00Z"}
+			]`,
+			want: []Event{
+				{Kind: EventKindHeadRefForcePushed, Actor: "author", SHA: "def456"},
+				{Kind: EventKindHeadRefDeleted, Actor: "maintainer"},
+				{Kind: EventKindHeadRefRestored, Actor: "maintainer"},
+			},
+		},
+		{
+			name: "draft status changes",
+			timeline: `[
+				{"event":"convert_to_draft","actor":{"login":"author"},"created_at":"2024-01-01T10:00:00Z"},
+				{"event":"ready_for_review","actor":{"login":"author"},"created_at":"2024-01-02T10:00:00Z"}
+			]`,
+			want: []Event{
+				{Kind: EventKindConvertToDraft, Actor: "author"},
+				{Kind: EventKindReadyForReview, Actor: "author"},
+			},
+		},
+		{
+			name: "state changes carry the commit",
+			timeline: `[
+				{"event":"closed","actor":{"login":"maintainer"},"created_at":"2024-01-01T10:00:00Z"},
+				{"event":"reopened","actor":{"login":"maintainer"},"created_at":"2024-01-02T10:00:00Z"},
+				{"event":"merged","actor":{"login":"maintainer"},"created_at":"2024-01-03T10:00:00Z","commit_id":"abc123"},
+				{"event":"closed","actor":{"login":"maintainer"},"created_at":"2024-01-03T10:00:00Z","commit_id":"abc123"}
+			]`,
+			want: []Event{
+				{Kind: EventKindClosed, Actor: "maintainer"},
+				{Kind: EventKindReopened, Actor: "maintainer"},
+				{Kind: EventKindMerged, Actor: "maintainer", SHA: "abc123"},
+				{Kind: EventKindClosed, Actor: "maintainer", SHA: "abc123"},
+			},
+		},
+		{
+			name: "auto-merge toggles",
+			timeline: `[
+				{"event":"auto_merge_enabled","actor":{"login":"author"},"created_at":"2024-01-01T10:00:00Z","auto_merge":{"merge_method":"squash"}},
+				{"event":"auto_merge_disabled","actor":{"login":"maintainer"},"created_at":"2024-01-02T10:00:00Z"}
+			]`,
+			want: []Event{
+				{Kind: EventKindAutoMergeEnabled, Actor: "author", Body: "squash"},
+				{Kind: EventKindAutoMergeDisabled, Actor: "maintainer"},
+			},
+		},
+		{
+			name: "title renames",
+			timeline: `[
+				{"event":"renamed","actor":{"login":"author"},"created_at":"2024-01-01T10:00:00Z","rename":{"from":"WIP: fix","to":"Fix login redirect"}},
+				{"event":"renamed","actor":{"login":"author"},"created_at":"2024-01-02T10:00:00Z"}
+			]`,
+			want: []Event{
+				{Kind: EventKindRenamed, Actor: "author", Body: "Fix login redirect", RenamedFrom: "WIP: fix"},
+			},
+		},
+		{
+			name: "dismissed review",
+			timeline: `[
+				{"event":"review_dismissed","actor":{"login":"maintainer"},"created_at":"2024-01-01T10:00:00Z",
+				 "dismissed_review":{"state":"approved","review_id":42,"dismissal_message":"Approval predates the force-push"}}
+			]`,
+			want: []Event{
+				{Kind: EventKindReviewDismissed, Actor: "maintainer", Outcome: "approved", Body: "Approval predates the force-push", ReviewID: 42},
+			},
+		},
+		{
+			name: "references",
+			timeline: `[
+				{"event":"cross-referenced","actor":{"login":"dev"},"created_at":"2024-01-01T10:00:00Z",
+				 "source":{"type":"issue","issue":{"number":12,"repository":{"full_name":"other/lib"}}}},
+				{"event":"cross-referenced","actor":{"login":"dev"},"created_at":"2024-01-02T10:00:00Z",
+				 "source":{"type":"issue","issue":{"number":7}}},
+				{"event":"referenced","actor":{"login":"dev"},"created_at":"2024-01-03T10:00:00Z","commit_id":"abc123"},
+				{"event":"referenced","actor":{"login":"dev"},"created_at":"2024-01-04T10:00:00Z"}
+			]`,
+			want: []Event{
+				{Kind: EventKindCrossReferenced, Actor: "dev", Target: "other/lib#12"},
+				{Kind: EventKindCrossReferenced, Actor: "dev", Target: "owner/repo#7"},
+				{Kind: EventKindReferenced, Actor: "dev", Target: "abc123", SHA: "abc123"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				if _, err := w.Write([]byte(tt.timeline)); err != nil {
+					http.Error(w, err.Error(), http.StatusInternalServerError)
+				}
+			}))
+			defer server.Close()
+
+			events, err := newTestClient(t, server).timelineEvents(context.Background(), "owner", "repo", 1)
+			if err != nil {
+				t.Fatalf("timelineEvents() error = %v", err)
+			}
+			if len(events) != len(tt.want) {
+				t.Fatalf("got %d events, want %d: %+v", len(events), len(tt.want), events)
+			}
+			for i, w := range tt.want {
+				got := events[i]
+				if got.Timestamp.IsZero() {
+					t.Errorf("event %d has no timestamp", i)
+				}
+				got.Timestamp = time.Time{}
+				if !reflect.DeepEqual(got, w) {
+					t.Errorf("event %d = %+v, want %+v", i, got, w)
+				}
+			}
+		})
+	}
+}
+
+func TestCommentReactions(t *testing.T) {
+	reactions := &githubReactions{TotalCount: 4, PlusOne: 2, Hooray: 1, Eyes: 1}
+	mock := &mockGithubClient{
+		responses: map[string]any{
+			"/repos/owner/repo/issues/1/comments?page=1&per_page=100": []*githubComment{
+				{User: &githubUser{Login: "dev"}, Body: "shipped", Reactions: reactions},
+				{User: &githubUser{Login: "dev"}, Body: "quiet", Reactions: &githubReactions{}},
+			},
+			"/repos/owner/repo/pulls/1/comments?page=1&per_page=100": []*githubReviewComment{
+				{User: &githubUser{Login: "reviewer"}, Body: "nice", Reactions: &githubReactions{TotalCount: 1, Heart: 1}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		opts           []Option
+		wantComment    map[string]int
+		wantReviewNote map[string]int
+	}{
+		{name: "disabled"},
+		{
+			name:           "enabled",
+			opts:           []Option{WithReactions()},
+			wantComment:    map[string]int{"+1": 2, "hooray": 1, "eyes": 1},
+			wantReviewNote: map[string]int{"heart": 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &Client{github: mock, logger: slog.Default()}
+			for _, opt := range tt.opts {
+				opt(client)
+			}
+			ctx := context.Background()
+			comments, err := client.comments(ctx, "owner", "repo", 1)
+			if err != nil {
+				t.Fatalf("comments() error = %v", err)
+			}
+			if !reflect.DeepEqual(comments[0].Reactions, tt.wantComment) {
+				t.Errorf("comment reactions = %v, want %v", comments[0].Reactions, tt.wantComment)
+			}
+			if comments[1].Reactions != nil {
+				t.Errorf("comment without reactions = %v, want nil", comments[1].Reactions)
+			}
+			reviewComments, err := client.reviewComments(ctx, "owner", "repo", 1)
+			if err != nil {
+				t.Fatalf("reviewComments() error = %v", err)
+			}
+			if !reflect.DeepEqual(reviewComments[0].Reactions, tt.wantReviewNote) {
+				t.Errorf("review comment reactions = %v, want %v", reviewComments[0].Reactions, tt.wantReviewNote)
+			}
+		})
+	}
+}
+
+func TestPaginateStopsEarly(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		page := r.URL.Query().Get("page")
+		w.Header().Set("Link", fmt.Sprintf(`<%s?page=%d&per_page=100>; rel="next"`, r.URL.Path, requests+1))
+		fmt.Fprintf(w, `[{"body":"%s-a"},{"body":"%s-b"}]`, page, page)
+	}))
+	defer server.Close()
+
+	var seen []string
+	err := paginate(context.Background(), newTestClient(t, server), "/repos/o/r/issues/1/comments", func(c *githubComment) error {
+		seen = append(seen, c.Body)
+		if c.Body == "2-a" {
+			return errStopPagination
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("paginate() error = %v, want nil after stopping", err)
+	}
+	if requests != 2 {
+		t.Errorf("fetched %d pages, want 2", requests)
+	}
+	if want := []string{"1-a", "1-b", "2-a"}; !slices.Equal(seen, want) {
+		t.Errorf("processed %q, want %q", seen, want)
+	}
+}
+
+func TestCommentsSince(t *testing.T) {
+	since := time.Date(2024, 3, 1, 9, 30, 0, 0, time.FixedZone("EST", -5*3600))
+	mock := &mockGithubClient{responses: map[string]any{}}
+	client := &Client{github: mock, logger: slog.Default(), since: since}
+	ctx := context.Background()
+
+	if _, err := client.comments(ctx, "owner", "repo", 1); err != nil {
+		t.Fatalf("comments() error = %v", err)
+	}
+	if _, err := client.reviewComments(ctx, "owner", "repo", 1); err != nil {
+		t.Fatalf("reviewComments() error = %v", err)
+	}
+	if _, err := client.reviews(ctx, "owner", "repo", 1); err != nil {
+		t.Fatalf("reviews() error = %v", err)
+	}
+	want := []string{
+		"/repos/owner/repo/issues/1/comments?since=2024-03-01T14%3A30%3A00Z&page=1&per_page=100",
+		"/repos/owner/repo/pulls/1/comments?since=2024-03-01T14%3A30%3A00Z&page=1&per_page=100",
+		"/repos/owner/repo/pulls/1/reviews?page=1&per_page=100",
+	}
+	if !slices.Equal(mock.calls, want) {
+		t.Errorf("requested %q, want %q", mock.calls, want)
+	}
+}
+
+func TestWithBotLogins(t *testing.T) {
+	mock := &mockGithubClient{
+		responses: map[string]any{
+			"/repos/owner/repo/issues/1/comments?page=1&per_page=100": []*githubComment{
+				{User: &githubUser{Login: "Deploy-Svc", Type: "User"}, Body: "deployed"},
+				{User: &githubUser{Login: "ops-bot", Type: "User"}, Body: "hi"},
+				{User: &githubUser{Login: "octocat", Type: "User"}, Body: "thanks"},
+				{User: &githubUser{Login: "release[bot]", Type: "Bot"}, Body: "released"},
+			},
+		},
+	}
+	client := NewClient("token", WithBotLogins([]string{"deploy-svc"}, []string{"ops-bot"}))
+	client.github = mock
+
+	events, err := client.comments(context.Background(), "owner", "repo", 1)
+	if err != nil {
+		t.Fatalf("comments() error = %v", err)
+	}
+	want := map[string]bool{"Deploy-Svc": true, "ops-bot": false, "octocat": false, "release[bot]": true}
+	for _, e := range events {
+		if e.Bot != want[e.Actor] {
+			t.Errorf("%s: Bot = %v, want %v", e.Actor, e.Bot, want[e.Actor])
+		}
+	}
+}
+
+func TestWithBotDetector(t *testing.T) {
+	mock := &mockGithubClient{
+		responses: map[string]any{
+			"/repos/owner/repo/issues/1/comments?page=1&per_page=100": []*githubComment{
+				{User: &githubUser{Login: "svc-deploy", Type: "User"}, Body: "deployed"},
+				{User: &githubUser{Login: "dependabot[bot]", Type: "Bot"}, Body: "bump"},
+				{User: &githubUser{Login: "svc-pinned", Type: "User"}, Body: "pinned"},
+			},
+		},
+	}
+	var seenTypes []string
+	detect := func(login, userType string) bool {
+		seenTypes = append(seenTypes, userType)
+		return strings.HasPrefix(login, "svc-")
+	}
+	client := NewClient("token", WithBotDetector(detect), WithBotLogins(nil, []string{"svc-pinned"}))
+	client.github = mock
+
+	events, err := client.comments(context.Background(), "owner", "repo", 1)
+	if err != nil {
+		t.Fatalf("comments() error = %v", err)
+	}
+	want := map[string]bool{"svc-deploy": true, "dependabot[bot]": false, "svc-pinned": false}
+	for _, e := range events {
+		if e.Bot != want[e.Actor] {
+			t.Errorf("%s: Bot = %v, want %v", e.Actor, e.Bot, want[e.Actor])
+		}
+	}
+	if !slices.Equal(seenTypes, []string{"User", "Bot"}) {
+		t.Errorf("detector saw user types %q, want [User Bot]", seenTypes)
+	}
+}
+
+func TestLatestCheckRuns(t *testing.T) {
+	start := time.Date(2024, 2, 1, 10, 0, 0, 0, time.UTC)
+	at := func(minutes int) githubTime { return githubTime{Time: start.Add(time.Duration(minutes) * time.Minute)} }
+	mock := &mockGithubClient{
+		responses: map[string]any{
+			"/repos/owner/repo/commits/abc123/check-runs?page=1&per_page=100": githubCheckRuns{CheckRuns: []*githubCheckRun{
+				{Name: "test", Conclusion: "success", StartedAt: at(20), CompletedAt: at(25)},
+				{Name: "build", Conclusion: "success", StartedAt: at(0), CompletedAt: at(3)},
+				{Name: "test", Conclusion: "failure", StartedAt: at(0), CompletedAt: at(6)},
+			}},
+		},
+	}
+	pr := &githubPullRequest{Head: githubRef{SHA: "abc123"}}
+
+	tests := []struct {
+		name string
+		opts []Option
+		want []string
+	}{
+		{name: "full history by default", want: []string{"test/success", "build/success", "test/failure"}},
+		{name: "latest attempt only", opts: []Option{WithLatestCheckRuns()}, want: []string{"test/success", "build/success"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewClient("token", tt.opts...)
+			c.github = mock
+			events, err := c.checkRuns(context.Background(), "owner", "repo", pr)
+			if err != nil {
+				t.Fatalf("checkRuns() error = %v", err)
+			}
+			var got []string
+			for _, e := range events {
+				got = append(got, e.Body+"/"+e.Outcome)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("check runs = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLatestStatuses(t *testing.T) {
+	start := time.Date(2024, 2, 1, 10, 0, 0, 0, time.UTC)
+	at := func(minutes int) githubTime { return githubTime{Time: start.Add(time.Duration(minutes) * time.Minute)} }
+	ci := &githubUser{Login: "ci"}
+	mock := &mockGithubClient{
+		responses: map[string]any{
+			"/repos/owner/repo/statuses/abc123?page=1&per_page=100": []*githubStatus{
+				{Context: "ci/build", State: "success", CreatedAt: at(5), Creator: ci},
+				{Context: "ci/lint", State: "failure", CreatedAt: at(2), Creator: ci},
+				{Context: "ci/build", State: "pending", CreatedAt: at(0), Creator: ci},
+			},
+		},
+	}
+	pr := &githubPullRequest{Head: githubRef{SHA: "abc123"}}
+
+	tests := []struct {
+		name string
+		opts []Option
+		want []string
+	}{
+		{name: "full history by default", want: []string{"ci/build/success", "ci/lint/failure", "ci/build/pending"}},
+		{name: "latest per context", opts: []Option{WithLatestStatuses()}, want: []string{"ci/build/success", "ci/lint/failure"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewClient("token", tt.opts...)
+			c.github = mock
+			events, err := c.statusChecks(context.Background(), "owner", "repo", pr)
+			if err != nil {
+				t.Fatalf("statusChecks() error = %v", err)
+			}
+			var got []string
+			for _, e := range events {
+				got = append(got, e.Body+"/"+e.Outcome)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("statuses = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCombinedStatus(t *testing.T) {
+	mock := &mockGithubClient{
+		responses: map[string]any{
+			"/repos/owner/repo/commits/abc123/status?page=1&per_page=100": json.RawMessage(`{
+				"state": "failure",
+				"sha": "abc123",
+				"total_count": 2,
+				"statuses": [
+					{"context": "ci/build", "state": "success", "created_at": "2024-02-01T10:05:00Z", "target_url": "https://ci.example.com/1"},
+					{"context": "ci/lint", "state": "failure", "created_at": "2024-02-01T10:02:00Z", "description": "3 errors"}
+				]
+			}`),
+		},
+	}
+	c := NewClient("token", WithCombinedStatus())
+	c.github = mock
+	pr := &githubPullRequest{Head: githubRef{SHA: "abc123"}}
+
+	events, err := c.statusChecks(context.Background(), "owner", "repo", pr)
+	if err != nil {
+		t.Fatalf("statusChecks() error = %v", err)
+	}
+	var got []string
+	for _, e := range events {
+		got = append(got, string(e.Kind)+":"+e.Body+"/"+e.Outcome)
+	}
+	want := []string{"status_check:ci/build/success", "status_check:ci/lint/failure", "combined_status:/failure"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("events = %q, want %q", got, want)
+	}
+	overall := events[2]
+	if wantTime := time.Date(2024, 2, 1, 10, 5, 0, 0, time.UTC); !overall.Timestamp.Equal(wantTime) {
+		t.Errorf("combined_status timestamp = %v, want %v", overall.Timestamp, wantTime)
+	}
+	if events[0].Actor != "unknown" {
+		t.Errorf("status actor = %q, want %q", events[0].Actor, "unknown")
+	}
+}
@@ -1,108 +1,380 @@
 package prx
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
 	"net/url"
+	"reflect"
+	"regexp"
+	"runtime/debug"
 	"strconv"
 	"strings"
 	"time"
+
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
 	githubAPI = "https://api.github.com"
+	// modulePath is used to look up the library version for the User-Agent.
+	modulePath = "github.com/ready-to-review/prx"
+	// defaultAccept is the media type sent with every API request unless overridden.
+	defaultAccept = "application/vnd.github.v3+json"
+	// timelinePreviewAccept is required by the timeline endpoint on older GitHub Enterprise versions.
+	timelinePreviewAccept = "application/vnd.github.mockingbird-preview+json"
+	// fullAccept returns comment bodies as markdown, rendered HTML, and plain text.
+	fullAccept = "application/vnd.github.full+json"
 	// maxResponseSize limits API response size to prevent memory exhaustion.
 	maxResponseSize = 10 * 1024 * 1024 // 10MB
 )
 
+// ErrUnexpectedResponse is returned when GitHub answers with data for something
+// other than what was requested, such as a different pull request. It usually
+// points at a misconfigured base URL or a proxy rewriting requests.
+var ErrUnexpectedResponse = errors.New("unexpected response from GitHub API")
+
 // GitHubAPIError represents an error response from the GitHub API.
 type GitHubAPIError struct {
 	StatusCode int
 	Status     string
 	Body       string
 	URL        string
+	// RateLimitRemaining is the number of requests left in the current rate limit
+	// window, or -1 if the response did not report it.
+	RateLimitRemaining int
+	// RateLimitReset is when the rate limit window resets; zero if not reported.
+	RateLimitReset time.Time
 }
 
 func (e *GitHubAPIError) Error() string {
-	return fmt.Sprintf("github API error: %s", e.Status)
+	return fmt.Sprintf("github API error: %s", redactTokens(e.Status))
+}
+
+// tokenPattern matches GitHub token formats and credentials following "Bearer" or "token".
+var tokenPattern = regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{20,}|github_pat_[A-Za-z0-9_]{20,})|(?i:\b(bearer|token)\s+)[A-Za-z0-9._~+/=-]{20,}`)
+
+// redactTokens replaces the given secrets, whatever their format, and anything
+// that looks like a GitHub credential in s.
+func redactTokens(s string, secrets ...string) string {
+	for _, secret := range secrets {
+		if secret != "" {
+			s = strings.ReplaceAll(s, secret, "[REDACTED]")
+		}
+	}
+	return tokenPattern.ReplaceAllStringFunc(s, func(m string) string {
+		if prefix, _, ok := strings.Cut(m, " "); ok {
+			return prefix + " [REDACTED]"
+		}
+		return "[REDACTED]"
+	})
+}
+
+// checkPullRequestNumber guards against a response for a different pull request than requested.
+func checkPullRequestNumber(pr *githubPullRequest, want int) error {
+	if pr.Number != want {
+		return fmt.Errorf("%w: requested pull request %d, got %d", ErrUnexpectedResponse, want, pr.Number)
+	}
+	return nil
+}
+
+// IsNotFound reports whether err is a 404 from the GitHub API, as returned for
+// deleted pull requests, renamed repositories, or resources the token cannot see.
+func IsNotFound(err error) bool {
+	var apiErr *GitHubAPIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// IsRateLimited reports whether err is GitHub refusing a request because a primary
+// or secondary rate limit was hit: a 429, or a 403 reporting no requests remaining
+// or whose body mentions the rate limit.
+// Requests are already retried before this is returned, so callers usually want to
+// stop or back off for a while rather than retry immediately.
+func IsRateLimited(err error) bool {
+	var apiErr *GitHubAPIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	switch apiErr.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusForbidden:
+		exhausted := apiErr.RateLimitRemaining == 0 && !apiErr.RateLimitReset.IsZero()
+		return exhausted || strings.Contains(strings.ToLower(apiErr.Body), "rate limit")
+	default:
+		return false
+	}
+}
+
+// isUnsupportedMediaType reports whether err is a 415 from the GitHub API,
+// meaning the endpoint wants a different Accept header.
+func isUnsupportedMediaType(err error) bool {
+	var apiErr *GitHubAPIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnsupportedMediaType
+}
+
+type acceptKey struct{}
+
+// withAccept returns a context whose API requests send mediaType as the Accept header.
+func withAccept(ctx context.Context, mediaType string) context.Context {
+	return context.WithValue(ctx, acceptKey{}, mediaType)
 }
 
 // githubClient is a client for interacting with the GitHub API.
 type githubClient struct {
 	client *http.Client
-	token  string
+	tokens TokenSource
 	api    string
+	rate   rateState  // Most recent rate limit reported by GitHub
+	etags  *etagState // Conditional request cache; nil when disabled
+	// userAgent identifies the caller to GitHub, which rejects requests without one.
+	userAgent string
+	logger    *slog.Logger
+	tracer    trace.Tracer // Records a span per request; nil when tracing is off
 }
 
 // newGithubClient creates a new githubClient.
 func newGithubClient(client *http.Client, token string) *githubClient {
-	return &githubClient{client: client, token: token, api: githubAPI}
+	return &githubClient{client: client, tokens: StaticToken(token), api: githubAPI, userAgent: defaultUserAgent, logger: discardLogger}
 }
 
+// discardLogger is the default logger: the library stays quiet unless WithLogger is used.
+var discardLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+// defaultUserAgent is "prx/<version>", using the module version recorded in the build.
+var defaultUserAgent = func() string {
+	version := "dev"
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, m := range append([]*debug.Module{&info.Main}, info.Deps...) {
+			if m.Path == modulePath && m.Version != "" && m.Version != "(devel)" {
+				version = m.Version
+				break
+			}
+		}
+	}
+	return "prx/" + version
+}()
+
 // doRequest performs the common HTTP request logic for GitHub API calls.
 func (c *githubClient) doRequest(ctx context.Context, path string) ([]byte, *githubResponse, error) {
-	apiURL := c.api + path
-	slog.InfoContext(ctx, "GitHub API request starting", "method", "GET", "url", apiURL)
+	return c.send(ctx, http.MethodGet, c.api+path, nil)
+}
 
-	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+// send makes a request to apiURL, an absolute URL on the configured API host.
+// Only GET requests are conditional; a body, if any, is sent as JSON.
+func (c *githubClient) send(ctx context.Context, method, apiURL string, payload []byte) (data []byte, parsed *githubResponse, err error) {
+	ctx, span := c.startSpan(ctx, method, apiURL)
+	defer func() { endSpan(span, err) }()
+
+	log := c.logger
+	if id := traceID(ctx); id != "" {
+		log = log.With("trace_id", id)
+	}
+	token, err := c.tokens.Token(ctx)
+	if err != nil {
+		log.ErrorContext(ctx, "GitHub token unavailable", "error", redactTokens(err.Error()))
+		return nil, nil, fmt.Errorf("getting GitHub token: %w", err)
+	}
+	// logURL is apiURL as it may appear in logs and errors.
+	logURL := redactTokens(apiURL, token)
+	log.InfoContext(ctx, "GitHub API request starting", "method", method, "url", logURL)
+
+	var reqBody io.Reader = http.NoBody
+	if payload != nil {
+		reqBody = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, apiURL, reqBody)
 	if err != nil {
 		return nil, nil, err
 	}
-	req.Header.Set("Authorization", "Bearer "+c.token)
-	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	// Without a token, requests are anonymous: public data only, at a lower rate limit.
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+	// Setting Accept-Encoding ourselves turns off the transport's transparent
+	// decompression, so gzip bodies are decoded below before the size limit applies.
+	req.Header.Set("Accept-Encoding", "gzip")
+	accept := defaultAccept
+	if a, ok := ctx.Value(acceptKey{}).(string); ok {
+		accept = a
+	}
+	req.Header.Set("Accept", accept)
+	key := etagKey(accept, apiURL)
+	var cached []byte
+	if method == http.MethodGet {
+		cached = c.etags.conditional(req, key)
+	}
+
+	if err := c.waitForRateLimit(ctx); err != nil {
+		log.WarnContext(ctx, "GitHub API request not sent", "url", logURL, "error", err)
+		return nil, nil, err
+	}
 
 	start := time.Now()
 	resp, err := c.client.Do(req)
 	elapsed := time.Since(start)
 	if err != nil {
-		slog.ErrorContext(ctx, "GitHub API request failed", "url", apiURL, "error", err, "elapsed", elapsed)
+		log.ErrorContext(ctx, "GitHub API request failed", "url", logURL, "error", redactTokens(err.Error(), token), "elapsed", elapsed)
 		return nil, nil, err
 	}
 	defer func() {
 		if closeErr := resp.Body.Close(); closeErr != nil {
-			slog.DebugContext(ctx, "failed to close response body", "error", closeErr, "url", apiURL)
+			log.DebugContext(ctx, "failed to close response body", "error", closeErr, "url", logURL)
 		}
 	}()
 
-	slog.InfoContext(ctx, "GitHub API response received", "status", resp.Status, "url", apiURL, "elapsed", elapsed)
+	log.InfoContext(ctx, "GitHub API response received", "status", resp.Status, "url", logURL, "elapsed", elapsed)
+	recordStatus(span, resp.StatusCode)
+	c.rate.update(resp.Header)
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		log.DebugContext(ctx, "GitHub API response not modified, using cached body", "url", logURL)
+		return cached, c.etags.response(key, c.parseResponse(ctx, req, resp)), nil
+	}
+
+	var body io.Reader = resp.Body
+	if strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		gz, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			log.ErrorContext(ctx, "GitHub API response is not valid gzip", "url", logURL, "error", err)
+			return nil, nil, fmt.Errorf("decompressing %s: %w", logURL, err)
+		}
+		defer func() {
+			if closeErr := gz.Close(); closeErr != nil {
+				log.DebugContext(ctx, "failed to close gzip reader", "error", closeErr, "url", logURL)
+			}
+		}()
+		body = gz
+	}
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
-		slog.ErrorContext(ctx, "GitHub API error", "status", resp.Status, "url", apiURL, "body", string(body))
-		return nil, nil, &GitHubAPIError{
-			StatusCode: resp.StatusCode,
-			Status:     resp.Status,
-			Body:       string(body),
-			URL:        apiURL,
+		raw, _ := io.ReadAll(io.LimitReader(body, 1024))
+		body := redactTokens(string(raw), token)
+		log.ErrorContext(ctx, "GitHub API error", "status", resp.Status, "url", logURL, "body", body)
+		apiErr := &GitHubAPIError{
+			StatusCode:         resp.StatusCode,
+			Status:             resp.Status,
+			Body:               body,
+			URL:                logURL,
+			RateLimitRemaining: -1,
+		}
+		if remaining, reset, ok := parseRateLimit(resp.Header); ok {
+			apiErr.RateLimitRemaining = remaining
+			apiErr.RateLimitReset = reset
 		}
+		return nil, nil, apiErr
 	}
 
-	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	// The limit counts decompressed bytes, so a small gzip body can't expand past it.
+	data, err = io.ReadAll(io.LimitReader(body, maxResponseSize+1))
 	if err != nil {
 		return nil, nil, err
 	}
+	if len(data) > maxResponseSize {
+		log.ErrorContext(ctx, "GitHub API response too large", "url", logURL, "limit", maxResponseSize)
+		return nil, nil, fmt.Errorf("%w: %s returned more than %d bytes", ErrMaxBytes, logURL, maxResponseSize)
+	}
 
+	parsed = c.parseResponse(ctx, req, resp)
+	if method == http.MethodGet {
+		c.etags.store(ctx, key, resp.Header, data, parsed)
+	}
+	return data, parsed, nil
+}
+
+// parseResponse extracts pagination and token metadata from response headers.
+func (c *githubClient) parseResponse(ctx context.Context, req *http.Request, resp *http.Response) *githubResponse {
 	// Parse Link header for pagination
 	nextPageNum := 0
-	linkHeader := resp.Header.Get("Link")
-	links := strings.Split(linkHeader, ",")
-	for _, link := range links {
-		parts := strings.Split(strings.TrimSpace(link), ";")
-		if len(parts) == 2 && strings.TrimSpace(parts[1]) == `rel="next"` {
-			u, err := url.Parse(strings.Trim(parts[0], "<>"))
-			if err == nil {
-				page := u.Query().Get("page")
-				nextPageNum, _ = strconv.Atoi(page)
+	nextPath := ""
+	if target := nextLink(resp.Header.Get("Link")); target != "" {
+		u, err := req.URL.Parse(target)
+		if err == nil {
+			page := u.Query().Get("page")
+			nextPageNum, _ = strconv.Atoi(page)
+			nextPath = c.relativePath(ctx, u)
+		}
+	}
+
+	var scopes []string
+	for _, scope := range strings.Split(resp.Header.Get("X-OAuth-Scopes"), ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	return &githubResponse{NextPage: nextPageNum, NextPath: nextPath, Scopes: scopes}
+}
+
+// nextLink returns the target of the rel="next" link in an RFC 8288 Link header, or "".
+// Links may carry any number of parameters in any order, and rel may list several
+// relation types (rel="next last"), so each parameter is inspected rather than
+// assuming GitHub's usual `<url>; rel="next"` shape.
+func nextLink(header string) string {
+	rest := header
+	for {
+		start := strings.IndexByte(rest, '<')
+		if start < 0 {
+			return ""
+		}
+		end := strings.IndexByte(rest[start:], '>')
+		if end < 0 {
+			return ""
+		}
+		target := rest[start+1 : start+end]
+		rest = rest[start+end+1:]
+
+		params := rest
+		if i := strings.IndexByte(rest, '<'); i >= 0 {
+			params = rest[:i]
+		}
+		for _, param := range strings.Split(params, ";") {
+			name, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if !ok || !strings.EqualFold(strings.TrimSpace(name), "rel") {
+				continue
+			}
+			value = strings.Trim(strings.TrimRight(strings.TrimSpace(value), ", "), `"`)
+			for _, rel := range strings.Fields(value) {
+				if strings.EqualFold(rel, "next") {
+					return target
+				}
 			}
-			break
 		}
 	}
+}
+
+// relativePath converts an absolute URL taken from a Link header into a path
+// relative to the configured API base. Enterprise proxies may advertise the
+// internal host or omit the API prefix; the path and query are kept but the
+// host is always the configured one, so the token is never sent elsewhere.
+func (c *githubClient) relativePath(ctx context.Context, u *url.URL) string {
+	base, err := url.Parse(c.api)
+	if err != nil {
+		return ""
+	}
+	if u.Host != base.Host {
+		c.logger.DebugContext(ctx, "Link header points at a different host, rebasing onto configured API",
+			"link_host", u.Host, "api_host", base.Host)
+	}
 
-	return data, &githubResponse{NextPage: nextPageNum}, nil
+	path := u.EscapedPath()
+	if prefix := strings.TrimSuffix(base.EscapedPath(), "/"); prefix != "" && strings.HasPrefix(path, prefix+"/") {
+		path = strings.TrimPrefix(path, prefix)
+	}
+	if u.RawQuery != "" {
+		path += "?" + u.RawQuery
+	}
+	return path
 }
 
 // get makes a GET request to the GitHub API and decodes the response into v.
@@ -112,7 +384,7 @@ func (c *githubClient) get(ctx context.Context, path string, v any) (*githubResp
 		return nil, err
 	}
 
-	if err := json.Unmarshal(data, v); err != nil {
+	if err := decodeJSON(ctx, c.logger, data, v); err != nil {
 		return nil, err
 	}
 
@@ -148,6 +420,117 @@ func (c *githubClient) userPermission(ctx context.Context, owner, repo, username
 // githubResponse wraps a GitHub API response.
 type githubResponse struct {
 	NextPage int
+	// NextPath is the next page's path and query relative to the API base,
+	// taken verbatim from the Link header so cursors and other parameters survive.
+	NextPath string
+	// Scopes lists the OAuth scopes granted to the token (X-OAuth-Scopes).
+	// It is empty for fine-grained and GitHub App tokens, which do not report scopes.
+	Scopes []string
+}
+
+// githubTime is a timestamp from the GitHub API. Some older GitHub Enterprise
+// releases emit timestamps that are not quite RFC 3339; rather than failing the
+// whole response, common variants are accepted and anything else decodes as the
+// zero time, and decodeJSON logs a warning with the rejected value.
+type githubTime struct {
+	time.Time
+	unparsed string // The value that could not be parsed, if any
+}
+
+// githubTimeLayouts are tried in order; layouts without a zone are read as UTC.
+var githubTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05 -0700",
+	"2006-01-02 15:04:05 MST",
+	"2006-01-02 15:04:05",
+}
+
+// UnmarshalJSON implements json.Unmarshaler and never fails. It has no logger at
+// hand, so a value it cannot parse is kept for decodeJSON to report.
+func (t *githubTime) UnmarshalJSON(data []byte) error {
+	*t = githubTime{}
+	if string(data) == "null" {
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		t.unparsed = string(data)
+		return nil
+	}
+	if s == "" {
+		return nil
+	}
+
+	for _, layout := range githubTimeLayouts {
+		if parsed, err := time.Parse(layout, s); err == nil {
+			t.Time = parsed
+			return nil
+		}
+	}
+	t.unparsed = s
+	return nil
+}
+
+// githubTimeType and timeType are the types unparsedTimes looks for and skips.
+var (
+	githubTimeType = reflect.TypeOf(githubTime{})
+	timeType       = reflect.TypeOf(time.Time{})
+)
+
+// decodeJSON unmarshals an API response into v, logging a warning for each
+// timestamp in it that decoded as the zero time because it could not be parsed.
+func decodeJSON(ctx context.Context, log *slog.Logger, data []byte, v any) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+	for _, value := range unparsedTimes(reflect.ValueOf(v), nil) {
+		log.WarnContext(ctx, "ignoring unparseable timestamp from GitHub", "value", truncate(value, 64))
+	}
+	return nil
+}
+
+// unparsedTimes appends to found the rejected input of every githubTime reachable from v.
+func unparsedTimes(v reflect.Value, found []string) []string {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			found = unparsedTimes(v.Elem(), found)
+		}
+	case reflect.Struct:
+		switch v.Type() {
+		case githubTimeType:
+			if s := v.FieldByName("unparsed").String(); s != "" {
+				found = append(found, s)
+			}
+		case timeType:
+			// A plain time.Time holds no rejected input.
+		default:
+			for i := range v.NumField() {
+				found = unparsedTimes(v.Field(i), found)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		switch v.Type().Elem().Kind() {
+		case reflect.Pointer, reflect.Interface, reflect.Struct, reflect.Slice, reflect.Array, reflect.Map:
+			for i := range v.Len() {
+				found = unparsedTimes(v.Index(i), found)
+			}
+		default:
+			// Scalars, including raw JSON, cannot hold timestamps.
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			found = unparsedTimes(iter.Value(), found)
+		}
+	default:
+		// Other scalars cannot hold timestamps.
+	}
+	return found
 }
 
 // githubUser represents a GitHub user.
@@ -159,29 +542,82 @@ type githubUser struct {
 // githubCommit represents a GitHub commit.
 type githubCommit struct {
 	Author struct {
-		Date time.Time `json:"date"`
+		Date githubTime `json:"date"`
 	} `json:"author"`
-	Message string `json:"message"`
+	Message      string `json:"message"`
+	Verification struct {
+		Verified bool `json:"verified"`
+	} `json:"verification"`
+}
+
+// githubCommitDetail represents a single commit from the commits API.
+type githubCommitDetail struct {
+	SHA       string               `json:"sha"`
+	Commit    githubCommit         `json:"commit"`
+	Committer *githubUser          `json:"committer"`
+	Parents   []githubCommitParent `json:"parents"`
+}
+
+// githubCommitParent identifies a parent of a commit.
+type githubCommitParent struct {
+	SHA string `json:"sha"`
+}
+
+// githubLabel represents a GitHub issue or pull request label.
+type githubLabel struct {
+	Name        string `json:"name"`
+	Color       string `json:"color"`       // Hex color without the leading "#"
+	Description string `json:"description"` // Empty or null when the label has none
+}
+
+// githubTeam represents a GitHub team.
+type githubTeam struct {
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+// githubRef represents the head or base branch of a pull request.
+type githubRef struct {
+	SHA  string      `json:"sha"`
+	Ref  string      `json:"ref"`
+	Repo *githubRepo `json:"repo"` // nil when the head fork has been deleted
+}
+
+// githubRepo represents a GitHub repository.
+type githubRepo struct {
+	FullName string `json:"full_name"` // "owner/repo"
+	Fork     bool   `json:"fork"`      // Whether the repository is itself a fork
+}
+
+// fromFork reports whether the pull request's head branch lives in a different
+// repository than its base. It is false when the head repository is unknown.
+func (pr *githubPullRequest) fromFork() bool {
+	return pr.Head.Repo != nil && pr.Base.Repo != nil && !strings.EqualFold(pr.Head.Repo.FullName, pr.Base.Repo.FullName)
 }
 
 // githubPullRequestCommit represents a commit in a pull request.
 type githubPullRequestCommit struct {
+	SHA    string       `json:"sha"`
 	Author *githubUser  `json:"author"`
 	Commit githubCommit `json:"commit"`
 }
 
 // githubComment represents a GitHub comment.
 type githubComment struct {
-	User              *githubUser `json:"user"`
-	CreatedAt         time.Time   `json:"created_at"`
-	Body              string      `json:"body"`
-	AuthorAssociation string      `json:"author_association"`
+	User              *githubUser      `json:"user"`
+	CreatedAt         githubTime       `json:"created_at"`
+	UpdatedAt         githubTime       `json:"updated_at"`
+	Body              string           `json:"body"`
+	BodyHTML          string           `json:"body_html"` // Only sent for the full or html media types
+	AuthorAssociation string           `json:"author_association"`
+	Reactions         *githubReactions `json:"reactions"`
 }
 
 // githubReview represents a GitHub review.
 type githubReview struct {
+	ID                int64       `json:"id"`
 	User              *githubUser `json:"user"`
-	SubmittedAt       time.Time   `json:"submitted_at"`
+	SubmittedAt       githubTime  `json:"submitted_at"`
 	State             string      `json:"state"`
 	Body              string      `json:"body"`
 	AuthorAssociation string      `json:"author_association"`
@@ -189,29 +625,87 @@ type githubReview struct {
 
 // githubReviewComment represents a GitHub review comment.
 type githubReviewComment struct {
-	User              *githubUser `json:"user"`
-	CreatedAt         time.Time   `json:"created_at"`
-	Body              string      `json:"body"`
-	AuthorAssociation string      `json:"author_association"`
+	ID                  int64            `json:"id"`
+	InReplyToID         int64            `json:"in_reply_to_id"` // First comment of the thread; 0 when this starts one
+	User                *githubUser      `json:"user"`
+	CreatedAt           githubTime       `json:"created_at"`
+	UpdatedAt           githubTime       `json:"updated_at"`
+	Body                string           `json:"body"`
+	AuthorAssociation   string           `json:"author_association"`
+	PullRequestReviewID int64            `json:"pull_request_review_id"`
+	Path                string           `json:"path"`
+	Position            *int             `json:"position"`      // Position in the current diff; null once outdated
+	Line                *int             `json:"line"`          // Line in the current diff; null once outdated
+	OriginalLine        *int             `json:"original_line"` // Line in the diff the comment was made on
+	DiffHunk            string           `json:"diff_hunk"`
+	BodyHTML            string           `json:"body_html"` // Only sent for the full or html media types
+	Reactions           *githubReactions `json:"reactions"`
+}
+
+// githubReactions is the reaction rollup GitHub includes with each comment.
+type githubReactions struct {
+	TotalCount int `json:"total_count"`
+	PlusOne    int `json:"+1"`
+	MinusOne   int `json:"-1"`
+	Laugh      int `json:"laugh"`
+	Hooray     int `json:"hooray"`
+	Confused   int `json:"confused"`
+	Heart      int `json:"heart"`
+	Rocket     int `json:"rocket"`
+	Eyes       int `json:"eyes"`
+}
+
+// counts returns the non-zero reactions keyed by GitHub's reaction names, or nil if there are none.
+func (r *githubReactions) counts() map[string]int {
+	if r == nil || r.TotalCount == 0 {
+		return nil
+	}
+	counts := make(map[string]int)
+	for name, n := range map[string]int{
+		"+1": r.PlusOne, "-1": r.MinusOne, "laugh": r.Laugh, "hooray": r.Hooray,
+		"confused": r.Confused, "heart": r.Heart, "rocket": r.Rocket, "eyes": r.Eyes,
+	} {
+		if n > 0 {
+			counts[name] = n
+		}
+	}
+	return counts
 }
 
 // githubTimelineEvent represents a GitHub timeline event.
 type githubTimelineEvent struct {
 	Event             string      `json:"event"`
 	Actor             *githubUser `json:"actor"`
-	CreatedAt         time.Time   `json:"created_at"`
+	CreatedAt         githubTime  `json:"created_at"`
 	AuthorAssociation string      `json:"author_association"`
 	Assignee          *githubUser `json:"assignee"`
-	Label             struct {
-		Name string `json:"name"`
-	} `json:"label"`
-	Milestone struct {
+	Label             githubLabel `json:"label"`
+	Milestone         struct {
 		Title string `json:"title"`
 	} `json:"milestone"`
 	RequestedReviewer *githubUser `json:"requested_reviewer"`
 	RequestedTeam     struct {
 		Name string `json:"name"`
 	} `json:"requested_team"`
+	CommitID  string `json:"commit_id"` // New head for head_ref_force_pushed; merge or closing commit for merged and closed
+	AutoMerge struct {
+		MergeMethod string `json:"merge_method"` // "merge", "squash", or "rebase"; not always sent
+	} `json:"auto_merge"`
+	Rename struct {
+		From string `json:"from"`
+		To   string `json:"to"`
+	} `json:"rename"`
+	DismissedReview struct {
+		State            string `json:"state"` // The review's state before dismissal, e.g. "approved"
+		ReviewID         int64  `json:"review_id"`
+		DismissalMessage string `json:"dismissal_message"`
+	} `json:"dismissed_review"`
+	Source struct {
+		Issue *struct {
+			Number     int         `json:"number"`
+			Repository *githubRepo `json:"repository"`
+		} `json:"issue"`
+	} `json:"source"` // The issue or pull request that mentioned this one, for cross-referenced
 }
 
 // githubStatus represents a GitHub status.
@@ -219,22 +713,50 @@ type githubStatus struct {
 	Context     string      `json:"context"`     // The status check name
 	Description string      `json:"description"` // Optional description
 	Creator     *githubUser `json:"creator"`
-	CreatedAt   time.Time   `json:"created_at"`
+	CreatedAt   githubTime  `json:"created_at"`
 	State       string      `json:"state"`
 	TargetURL   string      `json:"target_url"`
 }
 
+// githubCombinedStatus represents the rolled-up status of a commit.
+type githubCombinedStatus struct {
+	State      string          `json:"state"` // "success", "failure", "pending", or "error"
+	TotalCount int             `json:"total_count"`
+	Statuses   []*githubStatus `json:"statuses"` // Latest status for each context
+}
+
 // githubCheckRun represents a GitHub check run.
 type githubCheckRun struct {
 	Name string `json:"name"`
 	App  struct {
 		Owner *githubUser `json:"owner"`
 	} `json:"app"`
-	StartedAt   time.Time `json:"started_at"`
-	CompletedAt time.Time `json:"completed_at"`
-	Conclusion  string    `json:"conclusion"`
-	Status      string    `json:"status"`
-	HTMLURL     string    `json:"html_url"`
+	StartedAt   githubTime `json:"started_at"`
+	CompletedAt githubTime `json:"completed_at"`
+	Conclusion  string     `json:"conclusion"`
+	Status      string     `json:"status"`
+	HTMLURL     string     `json:"html_url"`
+	DetailsURL  string     `json:"details_url"` // Link to the run on the external CI system
+	ExternalID  string     `json:"external_id"` // The CI system's own identifier for the run
+	CheckSuite  struct {
+		ID int64 `json:"id"`
+	} `json:"check_suite"` // The suite, one per CI provider and commit, that the run belongs to
+}
+
+// url links to the run's logs: on the external CI system when it has one, otherwise on GitHub.
+func (r *githubCheckRun) url() string {
+	if r.DetailsURL != "" {
+		return r.DetailsURL
+	}
+	return r.HTMLURL
+}
+
+// elapsed returns how long the run took, or 0 unless it has both started and completed.
+func (r *githubCheckRun) elapsed() time.Duration {
+	if r.StartedAt.Time.IsZero() || r.CompletedAt.Time.IsZero() {
+		return 0
+	}
+	return max(r.CompletedAt.Time.Sub(r.StartedAt.Time), 0)
 }
 
 // githubCheckRuns represents a list of GitHub check runs.
@@ -244,24 +766,20 @@ type githubCheckRuns struct {
 
 // githubPullRequest represents a GitHub pull request.
 type githubPullRequest struct {
-	Number    int         `json:"number"`
-	Title     string      `json:"title"`
-	Body      string      `json:"body"`
-	CreatedAt time.Time   `json:"created_at"`
-	UpdatedAt time.Time   `json:"updated_at"`
-	User      *githubUser `json:"user"`
-	Merged    bool        `json:"merged"`
-	MergedAt  time.Time   `json:"merged_at"`
-	MergedBy  *githubUser `json:"merged_by"`
-	State     string      `json:"state"`
-	ClosedAt  time.Time   `json:"closed_at"`
-	Head      struct {
-		SHA string `json:"sha"`
-		Ref string `json:"ref"`
-	} `json:"head"`
-	Base struct {
-		Ref string `json:"ref"`
-	} `json:"base"`
+	Number             int           `json:"number"`
+	Title              string        `json:"title"`
+	Body               string        `json:"body"`
+	CreatedAt          githubTime    `json:"created_at"`
+	UpdatedAt          githubTime    `json:"updated_at"`
+	User               *githubUser   `json:"user"`
+	Merged             bool          `json:"merged"`
+	MergedAt           githubTime    `json:"merged_at"`
+	MergedBy           *githubUser   `json:"merged_by"`
+	MergeCommitSHA     string        `json:"merge_commit_sha"` // Commit that landed the PR on the base branch
+	State              string        `json:"state"`
+	ClosedAt           githubTime    `json:"closed_at"`
+	Head               githubRef     `json:"head"`
+	Base               githubRef     `json:"base"`
 	AuthorAssociation  string        `json:"author_association"`
 	Mergeable          *bool         `json:"mergeable"`           // Can be true, false, or null
 	MergeableState     string        `json:"mergeable_state"`     // "clean", "dirty", "blocked", "unstable", "unknown"
@@ -274,7 +792,6 @@ type githubPullRequest struct {
 	Comments           int           `json:"comments"`            // Number of issue comments
 	Assignees          []*githubUser `json:"assignees"`           // Current assignees
 	RequestedReviewers []*githubUser `json:"requested_reviewers"` // Pending reviewers
-	Labels             []struct {
-		Name string `json:"name"`
-	} `json:"labels"` // PR labels
+	RequestedTeams     []*githubTeam `json:"requested_teams"`     // Pending team reviews
+	Labels             []githubLabel `json:"labels"`              // PR labels
 }
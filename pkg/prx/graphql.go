@@ -0,0 +1,919 @@
+package prx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// WithGraphQL fetches a pull request and its events with a single GraphQL query
+// instead of a REST request per list and page, easing rate limit pressure in bulk
+// scans. Lists longer than one GraphQL page (100 items), such as a long commit
+// history, are still fetched over REST, as are permission lookups and linked issues.
+// It applies to PullRequest, PullRequestEvents, and StreamPullRequestEvents;
+// CacheClient uses REST.
+//
+// GraphQL reports only the latest state of each commit status context, as with
+// WithLatestStatuses, and check runs are attributed to their app's slug rather than
+// the account that owns the app.
+func WithGraphQL() Option {
+	return func(c *Client) {
+		c.graphQL = true
+	}
+}
+
+// WithGraphQLEventKinds limits GraphQL fetches to events of the given kinds, such
+// as EventKindReview, leaving the lists other kinds come from out of the query to
+// cut response size on huge pull requests. It implies WithGraphQL. Events of other
+// kinds are simply absent, never fetched over REST instead; the pull request's own
+// fields and its pr_opened, pr_merged, and pr_closed events are always included.
+func WithGraphQLEventKinds(kinds ...string) Option {
+	return func(c *Client) {
+		c.graphQL = true
+		c.graphQLKinds = make(map[string]bool, len(kinds))
+		for _, kind := range kinds {
+			c.graphQLKinds[kind] = true
+		}
+	}
+}
+
+// pullRequestQuery fetches everything the REST event sources do, one page of each,
+// leaving out the lists WithGraphQLEventKinds did not select.
+const pullRequestQuery = `
+query($owner: String!, $repo: String!, $number: Int!, $html: Boolean!, $diffHunks: Boolean!, $reactions: Boolean!,
+  $commits: Boolean!, $comments: Boolean!, $reviews: Boolean!, $reviewComments: Boolean!,
+  $statuses: Boolean!, $checkRuns: Boolean!, $timeline: Boolean!, $itemTypes: [PullRequestTimelineItemsItemType!]) {
+  repository(owner: $owner, name: $repo) {
+    pullRequest(number: $number) {
+      number title body state isDraft merged mergeable mergeStateStatus
+      createdAt updatedAt closedAt mergedAt
+      additions deletions changedFiles authorAssociation
+      author { __typename login }
+      mergedBy { __typename login }
+      mergeCommit { oid }
+      headRefName headRefOid baseRefName baseRefOid
+      headRepository { nameWithOwner }
+      baseRepository { nameWithOwner }
+      assignees(first: 100) { nodes { __typename login } }
+      reviewRequests(first: 100) { nodes { requestedReviewer { __typename ... on Actor { login } ... on Team { name } } } }
+      labels(first: 100) { nodes { name color description } }
+      commits(first: 100) @include(if: $commits) {
+        pageInfo { hasNextPage }
+        nodes { commit { oid message authoredDate signature { isValid } author { user { __typename login } } } }
+      }
+      headCommit: commits(last: 1) {
+        nodes {
+          commit {
+            status @include(if: $statuses) { state contexts { context state description targetUrl createdAt creator { __typename login } } }
+            checkSuites(first: 100) @include(if: $checkRuns) {
+              pageInfo { hasNextPage }
+              nodes {
+                databaseId
+                app { slug }
+                checkRuns(first: 100) {
+                  pageInfo { hasNextPage }
+                  nodes { name status conclusion startedAt completedAt detailsUrl url externalId }
+                }
+              }
+            }
+          }
+        }
+      }
+      comments(first: 100) @include(if: $comments) {
+        pageInfo { hasNextPage }
+        nodes {
+          body createdAt updatedAt authorAssociation
+          author { __typename login }
+          bodyHTML @include(if: $html)
+          reactionGroups @include(if: $reactions) { content reactors { totalCount } }
+        }
+      }
+      reviews(first: 100) @include(if: $reviews) {
+        pageInfo { hasNextPage }
+        nodes { databaseId state body submittedAt authorAssociation author { __typename login } }
+      }
+      reviewThreads(first: 100) @include(if: $reviewComments) {
+        pageInfo { hasNextPage }
+        nodes {
+          comments(first: 100) {
+            pageInfo { hasNextPage }
+            nodes {
+              databaseId body createdAt updatedAt authorAssociation path position line originalLine
+              author { __typename login }
+              replyTo { databaseId }
+              pullRequestReview { databaseId }
+              bodyHTML @include(if: $html)
+              diffHunk @include(if: $diffHunks)
+              reactionGroups @include(if: $reactions) { content reactors { totalCount } }
+            }
+          }
+        }
+      }
+      timelineItems(first: 100, itemTypes: $itemTypes) @include(if: $timeline) {
+        pageInfo { hasNextPage }
+        nodes {
+          __typename
+          ... on LabeledEvent { createdAt actor { __typename login } label { name color description } }
+          ... on UnlabeledEvent { createdAt actor { __typename login } label { name color description } }
+          ... on AssignedEvent { createdAt actor { __typename login } assignee { __typename ... on Actor { login } } }
+          ... on UnassignedEvent { createdAt actor { __typename login } assignee { __typename ... on Actor { login } } }
+          ... on MilestonedEvent { createdAt actor { __typename login } milestoneTitle }
+          ... on DemilestonedEvent { createdAt actor { __typename login } milestoneTitle }
+          ... on ReviewRequestedEvent { createdAt actor { __typename login } requestedReviewer { __typename ... on Actor { login } ... on Team { name } } }
+          ... on ReviewRequestRemovedEvent { createdAt actor { __typename login } requestedReviewer { __typename ... on Actor { login } ... on Team { name } } }
+          ... on RenamedTitleEvent { createdAt actor { __typename login } previousTitle currentTitle }
+          ... on ReadyForReviewEvent { createdAt actor { __typename login } }
+          ... on ConvertToDraftEvent { createdAt actor { __typename login } }
+          ... on ClosedEvent { createdAt actor { __typename login } }
+          ... on ReopenedEvent { createdAt actor { __typename login } }
+          ... on MergedEvent { createdAt actor { __typename login } commit { oid } }
+          ... on HeadRefForcePushedEvent { createdAt actor { __typename login } afterCommit { oid } }
+          ... on HeadRefDeletedEvent { createdAt actor { __typename login } }
+          ... on HeadRefRestoredEvent { createdAt actor { __typename login } }
+          ... on ReviewDismissedEvent { createdAt actor { __typename login } dismissalMessage previousReviewState review { databaseId } }
+          ... on CrossReferencedEvent { createdAt actor { __typename login } source { __typename ... on Issue { number repository { nameWithOwner } } ... on PullRequest { number repository { nameWithOwner } } } }
+          ... on ReferencedEvent { createdAt actor { __typename login } commit { oid } }
+          ... on AutoMergeEnabledEvent { createdAt actor { __typename login } }
+          ... on AutoMergeDisabledEvent { createdAt actor { __typename login } }
+        }
+      }
+    }
+  }
+}`
+
+// gqlTimelineKinds maps GraphQL timeline item types to their REST event names.
+var gqlTimelineKinds = map[string]string{
+	"LabeledEvent":              EventKindLabeled,
+	"UnlabeledEvent":            EventKindUnlabeled,
+	"AssignedEvent":             EventKindAssigned,
+	"UnassignedEvent":           EventKindUnassigned,
+	"MilestonedEvent":           EventKindMilestoned,
+	"DemilestonedEvent":         EventKindDemilestoned,
+	"ReviewRequestedEvent":      EventKindReviewRequested,
+	"ReviewRequestRemovedEvent": EventKindReviewRequestRemoved,
+	"RenamedTitleEvent":         EventKindRenamed,
+	"ReadyForReviewEvent":       EventKindReadyForReview,
+	"ConvertToDraftEvent":       EventKindConvertToDraft,
+	"ClosedEvent":               EventKindClosed,
+	"ReopenedEvent":             EventKindReopened,
+	"MergedEvent":               EventKindMerged,
+	"HeadRefForcePushedEvent":   EventKindHeadRefForcePushed,
+	"HeadRefDeletedEvent":       EventKindHeadRefDeleted,
+	"HeadRefRestoredEvent":      EventKindHeadRefRestored,
+	"ReviewDismissedEvent":      EventKindReviewDismissed,
+	"CrossReferencedEvent":      EventKindCrossReferenced,
+	"ReferencedEvent":           EventKindReferenced,
+	"AutoMergeEnabledEvent":     EventKindAutoMergeEnabled,
+	"AutoMergeDisabledEvent":    EventKindAutoMergeDisabled,
+}
+
+// gqlSourceKinds lists the event kinds each event source produces, apart from the
+// timeline, which produces those in gqlTimelineKinds.
+var gqlSourceKinds = map[string][]string{
+	"commits":         {EventKindCommit},
+	"comments":        {EventKindComment},
+	"reviews":         {EventKindReview},
+	"review comments": {EventKindReviewComment},
+	"status checks":   {EventKindStatusCheck, EventKindCombinedStatus},
+	"check runs":      {EventKindCheckRun},
+}
+
+// graphQLSelects reports whether any of kinds was selected with WithGraphQLEventKinds;
+// without a selection every kind is.
+func (c *Client) graphQLSelects(kinds ...string) bool {
+	if c.graphQLKinds == nil {
+		return true
+	}
+	return slices.ContainsFunc(kinds, func(kind string) bool { return c.graphQLKinds[kind] })
+}
+
+// graphQLItemTypes returns the timeline item types to query, as GraphQL enum values
+// such as LABELED_EVENT, in a stable order.
+func (c *Client) graphQLItemTypes() []string {
+	var itemTypes []string
+	for typename, kind := range gqlTimelineKinds {
+		if !c.graphQLSelects(kind) {
+			continue
+		}
+		var b strings.Builder
+		for i, r := range typename {
+			if i > 0 && unicode.IsUpper(r) {
+				b.WriteByte('_')
+			}
+			b.WriteRune(unicode.ToUpper(r))
+		}
+		itemTypes = append(itemTypes, b.String())
+	}
+	slices.Sort(itemTypes)
+	return itemTypes
+}
+
+// graphQLURL returns the GraphQL endpoint for a REST API root: /graphql on
+// api.github.com, or /api/graphql beside GitHub Enterprise Server's /api/v3.
+func graphQLURL(api string) string {
+	if base, ok := strings.CutSuffix(api, "/api/v3"); ok {
+		return base + "/api/graphql"
+	}
+	return api + "/graphql"
+}
+
+// graphql runs query with variables and decodes the response's data into v.
+// GitHub answers 200 even when a query fails; a NOT_FOUND error is returned as a
+// 404 GitHubAPIError so IsNotFound works the same as over REST.
+func (c *githubClient) graphql(ctx context.Context, query string, variables map[string]any, v any) error {
+	payload, err := json.Marshal(map[string]any{"query": query, "variables": variables})
+	if err != nil {
+		return err
+	}
+	apiURL := graphQLURL(c.api)
+	data, _, err := c.send(ctx, http.MethodPost, apiURL, payload)
+	if err != nil {
+		return err
+	}
+
+	var resp struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Type    string `json:"type"` // e.g. "NOT_FOUND"
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return fmt.Errorf("decoding GraphQL response: %w", err)
+	}
+	if len(resp.Errors) > 0 {
+		messages := make([]string, len(resp.Errors))
+		for i, e := range resp.Errors {
+			messages[i] = e.Message
+			if e.Type == "NOT_FOUND" {
+				return &GitHubAPIError{
+					StatusCode:         http.StatusNotFound,
+					Status:             "404 Not Found",
+					Body:               e.Message,
+					URL:                apiURL,
+					RateLimitRemaining: -1,
+				}
+			}
+		}
+		return fmt.Errorf("GraphQL query failed: %s", strings.Join(messages, "; "))
+	}
+	return decodeJSON(ctx, c.logger, resp.Data, v)
+}
+
+// graphQLPullRequest fetches a pull request and its timeline with one GraphQL query.
+// It returns the usual event sources, with those GraphQL answered in full served from
+// the query's results and the rest left to fetch over REST.
+func (c *Client) graphQLPullRequest(ctx context.Context, owner, repo string, prNumber int) (*githubPullRequest, []source, error) {
+	var data struct {
+		Repository *struct {
+			PullRequest *gqlPullRequest `json:"pullRequest"`
+		} `json:"repository"`
+	}
+	variables := map[string]any{
+		"owner":     owner,
+		"repo":      repo,
+		"number":    prNumber,
+		"html":      c.renderedBodies,
+		"diffHunks": c.diffHunks,
+		"reactions": c.reactions,
+
+		"commits":        c.graphQLSelects(EventKindCommit),
+		"comments":       c.graphQLSelects(EventKindComment),
+		"reviews":        c.graphQLSelects(EventKindReview),
+		"reviewComments": c.graphQLSelects(EventKindReviewComment),
+		"statuses":       c.graphQLSelects(EventKindStatusCheck, EventKindCombinedStatus),
+		"checkRuns":      c.graphQLSelects(EventKindCheckRun),
+	}
+	itemTypes := c.graphQLItemTypes()
+	variables["timeline"] = len(itemTypes) > 0
+	variables["itemTypes"] = itemTypes
+	if err := c.github.graphql(ctx, pullRequestQuery, variables, &data); err != nil {
+		c.logger.ErrorContext(ctx, "failed to fetch pull request", "error", err)
+		return nil, nil, fmt.Errorf("fetching pull request: %w", err)
+	}
+	if data.Repository == nil || data.Repository.PullRequest == nil {
+		return nil, nil, fmt.Errorf("%w: GraphQL response has no pull request %d", ErrUnexpectedResponse, prNumber)
+	}
+	g := data.Repository.PullRequest
+	pr := g.pullRequest()
+	if err := checkPullRequestNumber(pr, prNumber); err != nil {
+		c.logger.ErrorContext(ctx, "pull request response does not match request", "error", err)
+		return nil, nil, err
+	}
+
+	fetches := c.graphQLFetches(ctx, owner, repo, g)
+	sources := c.eventSources(ctx, owner, repo, pr)
+	for i, s := range sources {
+		kinds, core := gqlSourceKinds[s.name]
+		if !core && s.name != "timeline events" {
+			continue // Linked issues are fetched over REST
+		}
+		if fetch, ok := fetches[s.name]; ok {
+			sources[i].fetch = fetch
+		} else {
+			c.logger.DebugContext(ctx, "GraphQL results incomplete, fetching over REST", "source", s.name)
+		}
+		if c.graphQLKinds != nil {
+			sources[i].fetch = c.selectedEvents(kinds, sources[i].fetch)
+		}
+	}
+	return pr, sources, nil
+}
+
+// selectedEvents wraps a source's fetch to keep only the kinds selected with
+// WithGraphQLEventKinds, skipping the fetch altogether when none of kinds is.
+func (c *Client) selectedEvents(kinds []string, fetch func() ([]Event, error)) func() ([]Event, error) {
+	if kinds != nil && !c.graphQLSelects(kinds...) {
+		return func() ([]Event, error) { return nil, nil }
+	}
+	return func() ([]Event, error) {
+		events, err := fetch()
+		return slices.DeleteFunc(events, func(e Event) bool { return !c.graphQLKinds[e.Kind] }), err
+	}
+}
+
+// graphQLFetches returns, by source name, fetches that convert the lists GraphQL
+// returned in full into events.
+func (c *Client) graphQLFetches(ctx context.Context, owner, repo string, g *gqlPullRequest) map[string]func() ([]Event, error) {
+	fetches := make(map[string]func() ([]Event, error))
+	if !g.Commits.PageInfo.HasNextPage {
+		commits := make([]*githubPullRequestCommit, len(g.Commits.Nodes))
+		for i, n := range g.Commits.Nodes {
+			commits[i] = n.Commit.commit()
+		}
+		fetches["commits"] = eventsFrom(ctx, commits, func(commit *githubPullRequestCommit) *Event {
+			event := c.commitEvent(ctx, owner, repo, commit)
+			return &event
+		})
+	}
+
+	if !g.Comments.PageInfo.HasNextPage {
+		var comments []*githubComment
+		for _, n := range g.Comments.Nodes {
+			if c.updatedSince(n.UpdatedAt.Time) {
+				comments = append(comments, n.comment())
+			}
+		}
+		fetches["comments"] = eventsFrom(ctx, comments, func(comment *githubComment) *Event {
+			event := c.commentEvent(ctx, owner, repo, comment)
+			return &event
+		})
+	}
+
+	if !g.Reviews.PageInfo.HasNextPage {
+		reviews := make([]*githubReview, len(g.Reviews.Nodes))
+		for i, n := range g.Reviews.Nodes {
+			reviews[i] = n.review()
+		}
+		fetches["reviews"] = eventsFrom(ctx, reviews, func(review *githubReview) *Event {
+			if review.State == "" {
+				return nil
+			}
+			event := c.reviewEvent(ctx, owner, repo, review)
+			return &event
+		})
+	}
+
+	complete := !g.ReviewThreads.PageInfo.HasNextPage
+	var reviewComments []*githubReviewComment
+	for _, thread := range g.ReviewThreads.Nodes {
+		complete = complete && !thread.Comments.PageInfo.HasNextPage
+		for _, n := range thread.Comments.Nodes {
+			if c.updatedSince(n.UpdatedAt.Time) {
+				reviewComments = append(reviewComments, n.reviewComment())
+			}
+		}
+	}
+	if complete {
+		fetches["review comments"] = eventsFrom(ctx, reviewComments, func(comment *githubReviewComment) *Event {
+			event := c.reviewCommentEvent(ctx, owner, repo, comment)
+			return &event
+		})
+	}
+
+	if !g.TimelineItems.PageInfo.HasNextPage {
+		var items []*githubTimelineEvent
+		for _, n := range g.TimelineItems.Nodes {
+			if item := n.timelineEvent(); item != nil {
+				items = append(items, item)
+			}
+		}
+		fetches["timeline events"] = eventsFrom(ctx, items, func(item *githubTimelineEvent) *Event {
+			return c.parseTimelineEvent(ctx, owner, repo, item)
+		})
+	}
+
+	var head *gqlHeadCommit
+	if n := len(g.HeadCommit.Nodes); n > 0 {
+		head = &g.HeadCommit.Nodes[n-1].Commit
+	}
+	if head == nil {
+		// No commits means no statuses or check runs either.
+		fetches["status checks"] = eventsFrom[githubStatus](ctx, nil, nil)
+		fetches["check runs"] = eventsFrom[githubCheckRun](ctx, nil, nil)
+		return fetches
+	}
+
+	combined := &githubCombinedStatus{}
+	if head.Status != nil {
+		combined.State = strings.ToLower(head.Status.State)
+		for _, n := range head.Status.Contexts {
+			combined.Statuses = append(combined.Statuses, n.status())
+		}
+	}
+	statusEvents := eventsFrom(ctx, combined.Statuses, func(status *githubStatus) *Event {
+		event := c.statusEvent(status)
+		return &event
+	})
+	fetches["status checks"] = func() ([]Event, error) {
+		events, err := statusEvents()
+		if c.combinedStatus && len(combined.Statuses) > 0 {
+			events = appendEvent(ctx, events, combinedStatusEvent(combined))
+		}
+		return events, err
+	}
+
+	complete = !head.CheckSuites.PageInfo.HasNextPage
+	var runs []*githubCheckRun
+	for _, suite := range head.CheckSuites.Nodes {
+		complete = complete && !suite.CheckRuns.PageInfo.HasNextPage
+		for _, n := range suite.CheckRuns.Nodes {
+			runs = append(runs, n.checkRun(&suite))
+		}
+	}
+	if complete {
+		if c.latestCheckRuns {
+			runs = latestCheckRuns(runs)
+		}
+		fetches["check runs"] = eventsFrom(ctx, runs, func(run *githubCheckRun) *Event {
+			event := checkRunEvent(run)
+			return &event
+		})
+	}
+
+	return fetches
+}
+
+// eventsFrom returns a source fetch that converts items already in hand, skipping
+// those convert returns nil for.
+func eventsFrom[T any](ctx context.Context, items []*T, convert func(*T) *Event) func() ([]Event, error) {
+	return func() ([]Event, error) {
+		var events []Event
+		for _, item := range items {
+			if event := convert(item); event != nil {
+				events = appendEvent(ctx, events, *event)
+			}
+		}
+		return events, nil
+	}
+}
+
+// gqlConnection is one page of a GraphQL list.
+type gqlConnection[T any] struct {
+	PageInfo struct {
+		HasNextPage bool `json:"hasNextPage"`
+	} `json:"pageInfo"`
+	Nodes []T `json:"nodes"`
+}
+
+// gqlActor is a user, bot, or team as GraphQL reports it.
+type gqlActor struct {
+	Typename string `json:"__typename"`
+	Login    string `json:"login"`
+	Name     string `json:"name"` // Teams only
+}
+
+// user converts a to its REST form, in which bot logins end in "[bot]".
+func (a *gqlActor) user() *githubUser {
+	if a == nil || a.Login == "" {
+		return nil
+	}
+	if a.Typename == "Bot" {
+		return &githubUser{Login: a.Login + "[bot]", Type: "Bot"}
+	}
+	return &githubUser{Login: a.Login, Type: a.Typename}
+}
+
+// gqlOID is a reference to a commit by its object ID.
+type gqlOID struct {
+	OID string `json:"oid"`
+}
+
+// gqlDatabaseID is a reference to an object by its REST ID.
+type gqlDatabaseID struct {
+	DatabaseID int64 `json:"databaseId"`
+}
+
+// gqlReactionGroup counts one kind of reaction.
+type gqlReactionGroup struct {
+	Content  string `json:"content"` // e.g. "THUMBS_UP"
+	Reactors struct {
+		TotalCount int `json:"totalCount"`
+	} `json:"reactors"`
+}
+
+// gqlReactions converts reaction groups to the REST rollup, or nil if reactions were not requested.
+func gqlReactions(groups []gqlReactionGroup) *githubReactions {
+	if groups == nil {
+		return nil
+	}
+	r := &githubReactions{}
+	for _, g := range groups {
+		n := g.Reactors.TotalCount
+		r.TotalCount += n
+		switch g.Content {
+		case "THUMBS_UP":
+			r.PlusOne += n
+		case "THUMBS_DOWN":
+			r.MinusOne += n
+		case "LAUGH":
+			r.Laugh += n
+		case "HOORAY":
+			r.Hooray += n
+		case "CONFUSED":
+			r.Confused += n
+		case "HEART":
+			r.Heart += n
+		case "ROCKET":
+			r.Rocket += n
+		case "EYES":
+			r.Eyes += n
+		}
+	}
+	return r
+}
+
+// gqlPullRequest is the pullRequest object returned by pullRequestQuery.
+type gqlPullRequest struct {
+	Number            int                     `json:"number"`
+	Title             string                  `json:"title"`
+	Body              string                  `json:"body"`
+	State             string                  `json:"state"` // "OPEN", "CLOSED", or "MERGED"
+	IsDraft           bool                    `json:"isDraft"`
+	Merged            bool                    `json:"merged"`
+	Mergeable         string                  `json:"mergeable"`        // "MERGEABLE", "CONFLICTING", or "UNKNOWN"
+	MergeStateStatus  string                  `json:"mergeStateStatus"` // REST's mergeable_state, in upper case
+	CreatedAt         githubTime              `json:"createdAt"`
+	UpdatedAt         githubTime              `json:"updatedAt"`
+	ClosedAt          githubTime              `json:"closedAt"`
+	MergedAt          githubTime              `json:"mergedAt"`
+	Additions         int                     `json:"additions"`
+	Deletions         int                     `json:"deletions"`
+	ChangedFiles      int                     `json:"changedFiles"`
+	AuthorAssociation string                  `json:"authorAssociation"`
+	Author            *gqlActor               `json:"author"` // null for deleted accounts
+	MergedBy          *gqlActor               `json:"mergedBy"`
+	MergeCommit       *gqlOID                 `json:"mergeCommit"`
+	HeadRefName       string                  `json:"headRefName"`
+	HeadRefOID        string                  `json:"headRefOid"`
+	BaseRefName       string                  `json:"baseRefName"`
+	BaseRefOID        string                  `json:"baseRefOid"`
+	HeadRepository    *gqlRepository          `json:"headRepository"` // null when the head fork has been deleted
+	BaseRepository    *gqlRepository          `json:"baseRepository"`
+	Assignees         gqlConnection[gqlActor] `json:"assignees"`
+	ReviewRequests    gqlConnection[struct {
+		RequestedReviewer *gqlActor `json:"requestedReviewer"`
+	}] `json:"reviewRequests"`
+	Labels  gqlConnection[githubLabel] `json:"labels"`
+	Commits gqlConnection[struct {
+		Commit gqlCommit `json:"commit"`
+	}] `json:"commits"`
+	HeadCommit struct {
+		Nodes []struct {
+			Commit gqlHeadCommit `json:"commit"`
+		} `json:"nodes"`
+	} `json:"headCommit"`
+	Comments      gqlConnection[gqlComment] `json:"comments"`
+	Reviews       gqlConnection[gqlReview]  `json:"reviews"`
+	ReviewThreads gqlConnection[struct {
+		Comments gqlConnection[gqlReviewComment] `json:"comments"`
+	}] `json:"reviewThreads"`
+	TimelineItems gqlConnection[gqlTimelineItem] `json:"timelineItems"`
+}
+
+// gqlRepository identifies a repository.
+type gqlRepository struct {
+	NameWithOwner string `json:"nameWithOwner"` // "owner/repo"
+}
+
+// pullRequest converts g to its REST form.
+func (g *gqlPullRequest) pullRequest() *githubPullRequest {
+	pr := &githubPullRequest{
+		Number:            g.Number,
+		Title:             g.Title,
+		Body:              g.Body,
+		CreatedAt:         g.CreatedAt,
+		UpdatedAt:         g.UpdatedAt,
+		User:              g.Author.user(),
+		Merged:            g.Merged,
+		MergedAt:          g.MergedAt,
+		MergedBy:          g.MergedBy.user(),
+		State:             "open",
+		ClosedAt:          g.ClosedAt,
+		Head:              githubRef{SHA: g.HeadRefOID, Ref: g.HeadRefName},
+		Base:              githubRef{SHA: g.BaseRefOID, Ref: g.BaseRefName},
+		AuthorAssociation: g.AuthorAssociation,
+		MergeableState:    strings.ToLower(g.MergeStateStatus),
+		Draft:             g.IsDraft,
+		Additions:         g.Additions,
+		Deletions:         g.Deletions,
+		ChangedFiles:      g.ChangedFiles,
+	}
+	if g.State != "OPEN" {
+		pr.State = "closed"
+	}
+	// REST attributes deleted accounts to the "ghost" user rather than leaving the author empty.
+	if pr.User == nil {
+		pr.User = &githubUser{Login: "ghost", Type: "User"}
+	}
+	if g.MergeCommit != nil {
+		pr.MergeCommitSHA = g.MergeCommit.OID
+	}
+	switch g.Mergeable {
+	case "MERGEABLE":
+		mergeable := true
+		pr.Mergeable = &mergeable
+	case "CONFLICTING":
+		mergeable := false
+		pr.Mergeable = &mergeable
+	}
+	if g.HeadRepository != nil {
+		pr.Head.Repo = &githubRepo{FullName: g.HeadRepository.NameWithOwner}
+	}
+	if g.BaseRepository != nil {
+		pr.Base.Repo = &githubRepo{FullName: g.BaseRepository.NameWithOwner}
+	}
+	for i := range g.Assignees.Nodes {
+		if user := g.Assignees.Nodes[i].user(); user != nil {
+			pr.Assignees = append(pr.Assignees, user)
+		}
+	}
+	for _, n := range g.ReviewRequests.Nodes {
+		switch reviewer := n.RequestedReviewer; {
+		case reviewer == nil:
+		case reviewer.Typename == "Team":
+			pr.RequestedTeams = append(pr.RequestedTeams, &githubTeam{Name: reviewer.Name})
+		default:
+			if user := reviewer.user(); user != nil {
+				pr.RequestedReviewers = append(pr.RequestedReviewers, user)
+			}
+		}
+	}
+	pr.Labels = g.Labels.Nodes
+	return pr
+}
+
+// gqlCommit is a commit on the pull request.
+type gqlCommit struct {
+	OID          string     `json:"oid"`
+	Message      string     `json:"message"`
+	AuthoredDate githubTime `json:"authoredDate"`
+	Signature    *struct {
+		IsValid bool `json:"isValid"`
+	} `json:"signature"` // null for unsigned commits
+	Author struct {
+		User *gqlActor `json:"user"` // null when the author's email matches no account
+	} `json:"author"`
+}
+
+// commit converts g to its REST form.
+func (g *gqlCommit) commit() *githubPullRequestCommit {
+	commit := &githubPullRequestCommit{SHA: g.OID, Author: g.Author.User.user()}
+	commit.Commit.Author.Date = g.AuthoredDate
+	commit.Commit.Message = g.Message
+	commit.Commit.Verification.Verified = g.Signature != nil && g.Signature.IsValid
+	return commit
+}
+
+// gqlHeadCommit carries the CI results for the pull request's head commit.
+type gqlHeadCommit struct {
+	Status *struct {
+		State    string             `json:"state"` // Upper case, e.g. "SUCCESS"
+		Contexts []gqlStatusContext `json:"contexts"`
+	} `json:"status"` // null when the commit has no statuses
+	CheckSuites gqlConnection[gqlCheckSuite] `json:"checkSuites"`
+}
+
+// gqlStatusContext is the latest status of one context.
+type gqlStatusContext struct {
+	Context     string     `json:"context"`
+	State       string     `json:"state"`
+	Description string     `json:"description"`
+	TargetURL   string     `json:"targetUrl"`
+	CreatedAt   githubTime `json:"createdAt"`
+	Creator     *gqlActor  `json:"creator"`
+}
+
+// status converts g to its REST form.
+func (g *gqlStatusContext) status() *githubStatus {
+	return &githubStatus{
+		Context:     g.Context,
+		Description: g.Description,
+		Creator:     g.Creator.user(),
+		CreatedAt:   g.CreatedAt,
+		State:       strings.ToLower(g.State),
+		TargetURL:   g.TargetURL,
+	}
+}
+
+// gqlCheckSuite groups the check runs one app made on a commit.
+type gqlCheckSuite struct {
+	DatabaseID int64 `json:"databaseId"`
+	App        *struct {
+		Slug string `json:"slug"`
+	} `json:"app"`
+	CheckRuns gqlConnection[gqlCheckRun] `json:"checkRuns"`
+}
+
+// gqlCheckRun is one check run in a suite.
+type gqlCheckRun struct {
+	Name        string     `json:"name"`
+	Status      string     `json:"status"`     // Upper case, e.g. "COMPLETED"
+	Conclusion  string     `json:"conclusion"` // Upper case, e.g. "SUCCESS"; null until completed
+	StartedAt   githubTime `json:"startedAt"`
+	CompletedAt githubTime `json:"completedAt"`
+	DetailsURL  string     `json:"detailsUrl"`
+	URL         string     `json:"url"`
+	ExternalID  string     `json:"externalId"`
+}
+
+// checkRun converts g, which belongs to suite, to its REST form.
+func (g *gqlCheckRun) checkRun(suite *gqlCheckSuite) *githubCheckRun {
+	run := &githubCheckRun{
+		Name:        g.Name,
+		StartedAt:   g.StartedAt,
+		CompletedAt: g.CompletedAt,
+		Conclusion:  strings.ToLower(g.Conclusion),
+		Status:      strings.ToLower(g.Status),
+		HTMLURL:     g.URL,
+		DetailsURL:  g.DetailsURL,
+		ExternalID:  g.ExternalID,
+	}
+	run.CheckSuite.ID = suite.DatabaseID
+	if suite.App != nil {
+		run.App.Owner = &githubUser{Login: suite.App.Slug, Type: "Bot"}
+	}
+	return run
+}
+
+// gqlComment is an issue comment on the pull request.
+type gqlComment struct {
+	Body              string             `json:"body"`
+	BodyHTML          string             `json:"bodyHTML"`
+	CreatedAt         githubTime         `json:"createdAt"`
+	UpdatedAt         githubTime         `json:"updatedAt"`
+	AuthorAssociation string             `json:"authorAssociation"`
+	Author            *gqlActor          `json:"author"`
+	ReactionGroups    []gqlReactionGroup `json:"reactionGroups"`
+}
+
+// comment converts g to its REST form.
+func (g *gqlComment) comment() *githubComment {
+	return &githubComment{
+		User:              g.Author.user(),
+		CreatedAt:         g.CreatedAt,
+		UpdatedAt:         g.UpdatedAt,
+		Body:              g.Body,
+		BodyHTML:          g.BodyHTML,
+		AuthorAssociation: g.AuthorAssociation,
+		Reactions:         gqlReactions(g.ReactionGroups),
+	}
+}
+
+// gqlReview is a submitted or pending review.
+type gqlReview struct {
+	DatabaseID        int64      `json:"databaseId"`
+	State             string     `json:"state"`
+	Body              string     `json:"body"`
+	SubmittedAt       githubTime `json:"submittedAt"`
+	AuthorAssociation string     `json:"authorAssociation"`
+	Author            *gqlActor  `json:"author"`
+}
+
+// review converts g to its REST form. Review states are upper case in both APIs.
+func (g *gqlReview) review() *githubReview {
+	return &githubReview{
+		ID:                g.DatabaseID,
+		User:              g.Author.user(),
+		SubmittedAt:       g.SubmittedAt,
+		State:             g.State,
+		Body:              g.Body,
+		AuthorAssociation: g.AuthorAssociation,
+	}
+}
+
+// gqlReviewComment is an inline comment in a review thread.
+type gqlReviewComment struct {
+	DatabaseID        int64              `json:"databaseId"`
+	Body              string             `json:"body"`
+	BodyHTML          string             `json:"bodyHTML"`
+	CreatedAt         githubTime         `json:"createdAt"`
+	UpdatedAt         githubTime         `json:"updatedAt"`
+	AuthorAssociation string             `json:"authorAssociation"`
+	Path              string             `json:"path"`
+	Position          *int               `json:"position"`
+	Line              *int               `json:"line"`
+	OriginalLine      *int               `json:"originalLine"`
+	DiffHunk          string             `json:"diffHunk"`
+	Author            *gqlActor          `json:"author"`
+	ReplyTo           *gqlDatabaseID     `json:"replyTo"`
+	PullRequestReview *gqlDatabaseID     `json:"pullRequestReview"`
+	ReactionGroups    []gqlReactionGroup `json:"reactionGroups"`
+}
+
+// reviewComment converts g to its REST form.
+func (g *gqlReviewComment) reviewComment() *githubReviewComment {
+	comment := &githubReviewComment{
+		ID:                g.DatabaseID,
+		User:              g.Author.user(),
+		CreatedAt:         g.CreatedAt,
+		UpdatedAt:         g.UpdatedAt,
+		Body:              g.Body,
+		AuthorAssociation: g.AuthorAssociation,
+		Path:              g.Path,
+		Position:          g.Position,
+		Line:              g.Line,
+		OriginalLine:      g.OriginalLine,
+		DiffHunk:          g.DiffHunk,
+		BodyHTML:          g.BodyHTML,
+		Reactions:         gqlReactions(g.ReactionGroups),
+	}
+	if g.ReplyTo != nil {
+		comment.InReplyToID = g.ReplyTo.DatabaseID
+	}
+	if g.PullRequestReview != nil {
+		comment.PullRequestReviewID = g.PullRequestReview.DatabaseID
+	}
+	return comment
+}
+
+// gqlTimelineItem holds the fields of every timeline item type pullRequestQuery asks for.
+type gqlTimelineItem struct {
+	Typename            string         `json:"__typename"`
+	CreatedAt           githubTime     `json:"createdAt"`
+	Actor               *gqlActor      `json:"actor"`
+	Label               *githubLabel   `json:"label"`
+	Assignee            *gqlActor      `json:"assignee"`
+	MilestoneTitle      string         `json:"milestoneTitle"`
+	RequestedReviewer   *gqlActor      `json:"requestedReviewer"`
+	PreviousTitle       string         `json:"previousTitle"`
+	CurrentTitle        string         `json:"currentTitle"`
+	Commit              *gqlOID        `json:"commit"`
+	AfterCommit         *gqlOID        `json:"afterCommit"`
+	DismissalMessage    string         `json:"dismissalMessage"`
+	PreviousReviewState string         `json:"previousReviewState"`
+	Review              *gqlDatabaseID `json:"review"`
+	Source              *struct {
+		Number     int            `json:"number"`
+		Repository *gqlRepository `json:"repository"`
+	} `json:"source"`
+}
+
+// timelineEvent converts g to its REST form, or returns nil for an unknown item type.
+func (g *gqlTimelineItem) timelineEvent() *githubTimelineEvent {
+	kind, ok := gqlTimelineKinds[g.Typename]
+	if !ok {
+		return nil
+	}
+	item := &githubTimelineEvent{
+		Event:     kind,
+		Actor:     g.Actor.user(),
+		CreatedAt: g.CreatedAt,
+		Assignee:  g.Assignee.user(),
+	}
+	if g.Label != nil {
+		item.Label = *g.Label
+	}
+	item.Milestone.Title = g.MilestoneTitle
+	if reviewer := g.RequestedReviewer; reviewer != nil && reviewer.Typename == "Team" {
+		item.RequestedTeam.Name = reviewer.Name
+	} else {
+		item.RequestedReviewer = reviewer.user()
+	}
+	item.Rename.From, item.Rename.To = g.PreviousTitle, g.CurrentTitle
+	switch {
+	case g.AfterCommit != nil:
+		item.CommitID = g.AfterCommit.OID
+	case g.Commit != nil:
+		item.CommitID = g.Commit.OID
+	}
+	item.DismissedReview.State = g.PreviousReviewState
+	item.DismissedReview.DismissalMessage = g.DismissalMessage
+	if g.Review != nil {
+		item.DismissedReview.ReviewID = g.Review.DatabaseID
+	}
+	if g.Source != nil && g.Source.Number != 0 {
+		item.Source.Issue = &struct {
+			Number     int         `json:"number"`
+			Repository *githubRepo `json:"repository"`
+		}{Number: g.Source.Number}
+		if g.Source.Repository != nil {
+			item.Source.Issue.Repository = &githubRepo{FullName: g.Source.Repository.NameWithOwner}
+		}
+	}
+	return item
+}
@@ -0,0 +1,294 @@
+package prx
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// graphQLPullRequestResponse is a GraphQL answer for owner/repo#1; commitsHasNextPage
+// is substituted to make the commit list overflow its page.
+const graphQLPullRequestResponse = `{"data": {"repository": {"pullRequest": {
+	"number": 1, "title": "Add retries", "body": "Retries failed requests.", "state": "MERGED",
+	"isDraft": false, "merged": true, "mergeable": "UNKNOWN", "mergeStateStatus": "UNKNOWN",
+	"createdAt": "2024-03-01T09:00:00Z", "updatedAt": "2024-03-02T12:00:00Z",
+	"closedAt": "2024-03-02T12:00:00Z", "mergedAt": "2024-03-02T12:00:00Z",
+	"additions": 40, "deletions": 2, "changedFiles": 3, "authorAssociation": "OWNER",
+	"author": {"__typename": "User", "login": "author"},
+	"mergedBy": {"__typename": "User", "login": "author"},
+	"mergeCommit": {"oid": "merge1"},
+	"headRefName": "retries", "headRefOid": "head1", "baseRefName": "main", "baseRefOid": "base1",
+	"headRepository": {"nameWithOwner": "owner/repo"}, "baseRepository": {"nameWithOwner": "owner/repo"},
+	"assignees": {"nodes": [{"__typename": "User", "login": "author"}]},
+	"reviewRequests": {"nodes": [{"requestedReviewer": {"__typename": "Team", "name": "Platform"}}]},
+	"labels": {"nodes": [{"name": "enhancement", "color": "a2eeef", "description": null}]},
+	"commits": {"pageInfo": {"hasNextPage": commitsHasNextPage}, "nodes": [
+		{"commit": {"oid": "head1", "message": "Add retries", "authoredDate": "2024-03-01T08:30:00Z", "signature": {"isValid": true}, "author": {"user": null}}}
+	]},
+	"headCommit": {"nodes": [{"commit": {
+		"status": {"state": "FAILURE", "contexts": [
+			{"context": "ci/lint", "state": "FAILURE", "description": "", "targetUrl": "https://ci.example.com/7", "createdAt": "2024-03-01T09:10:00Z", "creator": {"__typename": "User", "login": "ci"}}
+		]},
+		"checkSuites": {"pageInfo": {"hasNextPage": false}, "nodes": [
+			{"databaseId": 55, "app": {"slug": "github-actions"}, "checkRuns": {"pageInfo": {"hasNextPage": false}, "nodes": [
+				{"name": "test", "status": "COMPLETED", "conclusion": "SUCCESS", "startedAt": "2024-03-01T09:00:00Z", "completedAt": "2024-03-01T09:04:00Z", "detailsUrl": "", "url": "https://github.com/owner/repo/runs/9", "externalId": ""}
+			]}}
+		]}
+	}}]},
+	"comments": {"pageInfo": {"hasNextPage": false}, "nodes": [
+		{"body": "Thanks!", "createdAt": "2024-03-01T10:00:00Z", "updatedAt": "2024-03-01T10:00:00Z", "authorAssociation": "NONE", "author": {"__typename": "Bot", "login": "renovate"}}
+	]},
+	"reviews": {"pageInfo": {"hasNextPage": false}, "nodes": [
+		{"databaseId": 300, "state": "APPROVED", "body": "", "submittedAt": "2024-03-02T11:00:00Z", "authorAssociation": "OWNER", "author": {"__typename": "User", "login": "author"}}
+	]},
+	"reviewThreads": {"pageInfo": {"hasNextPage": false}, "nodes": [
+		{"comments": {"pageInfo": {"hasNextPage": false}, "nodes": [
+			{"databaseId": 400, "body": "Why three?", "createdAt": "2024-03-02T10:00:00Z", "updatedAt": "2024-03-02T10:00:00Z", "authorAssociation": "OWNER", "path": "retry.go", "position": 4, "line": 12, "originalLine": 12, "author": {"__typename": "User", "login": "author"}, "replyTo": null, "pullRequestReview": {"databaseId": 300}},
+			{"databaseId": 401, "body": "GitHub's advice.", "createdAt": "2024-03-02T10:05:00Z", "updatedAt": "2024-03-02T10:05:00Z", "authorAssociation": "OWNER", "path": "retry.go", "position": null, "line": null, "originalLine": 12, "author": {"__typename": "User", "login": "author"}, "replyTo": {"databaseId": 400}, "pullRequestReview": {"databaseId": 301}}
+		]}}
+	]},
+	"timelineItems": {"pageInfo": {"hasNextPage": false}, "nodes": [
+		{"__typename": "LabeledEvent", "createdAt": "2024-03-01T09:05:00Z", "actor": {"__typename": "User", "login": "author"}, "label": {"name": "enhancement", "color": "a2eeef", "description": null}},
+		{"__typename": "ReviewRequestedEvent", "createdAt": "2024-03-01T09:06:00Z", "actor": {"__typename": "User", "login": "author"}, "requestedReviewer": {"__typename": "Team", "name": "Platform"}},
+		{"__typename": "MergedEvent", "createdAt": "2024-03-02T12:00:00Z", "actor": {"__typename": "User", "login": "author"}, "commit": {"oid": "merge1"}}
+	]}
+}}}}`
+
+func TestGraphQLPullRequest(t *testing.T) {
+	var mu sync.Mutex
+	var requests []string
+	var variables map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+		if r.Method != http.MethodPost || r.URL.Path != "/graphql" {
+			http.NotFound(w, r)
+			return
+		}
+		var body struct {
+			Query     string         `json:"query"`
+			Variables map[string]any `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || !strings.Contains(body.Query, "pullRequest(number: $number)") {
+			http.Error(w, "bad query", http.StatusBadRequest)
+			return
+		}
+		variables = body.Variables
+		if _, err := w.Write([]byte(strings.Replace(graphQLPullRequestResponse, "commitsHasNextPage", "false", 1))); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server, WithGraphQL())
+	data, err := client.PullRequest(context.Background(), "owner", "repo", 1)
+	if err != nil {
+		t.Fatalf("PullRequest() error = %v", err)
+	}
+
+	if want := []string{"POST /graphql"}; !slices.Equal(requests, want) {
+		t.Errorf("requests = %q, want %q", requests, want)
+	}
+	if variables["owner"] != "owner" || variables["repo"] != "repo" || variables["number"] != float64(1) {
+		t.Errorf("variables = %v, want owner/repo#1", variables)
+	}
+
+	pr := data.PullRequest
+	if pr.State != "closed" || !pr.Merged || pr.MergedBy != "author" || pr.HeadSHA != "head1" || pr.Additions != 40 {
+		t.Errorf("pull request = %+v, want merged PR at head1 by author", pr)
+	}
+	if !slices.Equal(pr.RequestedTeams, []string{"Platform"}) || !slices.Equal(pr.Labels, []string{"enhancement"}) {
+		t.Errorf("teams = %q, labels = %q", pr.RequestedTeams, pr.Labels)
+	}
+
+	var kinds []string
+	byKind := make(map[string][]Event)
+	for _, e := range data.Events {
+		kinds = append(kinds, e.Kind)
+		byKind[e.Kind] = append(byKind[e.Kind], e)
+	}
+	want := []string{"commit", "pr_opened", "check_run", "labeled", "review_requested", "status_check",
+		"comment", "review_comment", "review_comment", "review", "merged", "pr_merged"}
+	if !slices.Equal(kinds, want) {
+		t.Fatalf("kinds = %q, want %q", kinds, want)
+	}
+
+	if c := byKind["comment"][0]; c.Actor != "renovate[bot]" || !c.Bot {
+		t.Errorf("comment actor = %q (bot %v), want renovate[bot]", c.Actor, c.Bot)
+	}
+	if c := byKind["commit"][0]; c.SHA != "head1" || !c.Verified || c.Actor != "unknown" {
+		t.Errorf("commit = %+v, want verified head1 by unknown", c)
+	}
+	if r := byKind["review"][0]; r.Outcome != "approved" || r.ReviewID != 300 {
+		t.Errorf("review = %+v, want approval 300", r)
+	}
+	reply := byKind["review_comment"][1]
+	if reply.CommentID != 401 || reply.InReplyTo != 400 || !reply.Outdated || reply.OriginalLine != 12 {
+		t.Errorf("reply = %+v, want outdated reply 401 to 400", reply)
+	}
+	if s := byKind["status_check"][0]; s.Outcome != "failure" || s.URL != "https://ci.example.com/7" {
+		t.Errorf("status = %+v, want failing ci/lint with target URL", s)
+	}
+	if r := byKind["check_run"][0]; r.Outcome != "success" || r.CheckSuiteID != 55 || r.URL != "https://github.com/owner/repo/runs/9" {
+		t.Errorf("check run = %+v, want successful run in suite 55", r)
+	}
+	if m := byKind["merged"][0]; m.SHA != "merge1" {
+		t.Errorf("merged SHA = %q, want merge1", m.SHA)
+	}
+}
+
+func TestGraphQLFallsBackToREST(t *testing.T) {
+	var mu sync.Mutex
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+		var body string
+		switch r.URL.Path {
+		case "/graphql":
+			body = strings.Replace(graphQLPullRequestResponse, "commitsHasNextPage", "true", 1)
+		case "/repos/owner/repo/pulls/1/commits":
+			body = `[{"sha": "c1", "author": null, "commit": {"message": "one", "author": {"date": "2024-03-01T08:00:00Z"}}}]`
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		if _, err := w.Write([]byte(body)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server, WithGraphQL())
+	data, err := client.PullRequest(context.Background(), "owner", "repo", 1)
+	if err != nil {
+		t.Fatalf("PullRequest() error = %v", err)
+	}
+
+	slices.Sort(requests)
+	if want := []string{"GET /repos/owner/repo/pulls/1/commits", "POST /graphql"}; !slices.Equal(requests, want) {
+		t.Errorf("requests = %q, want %q", requests, want)
+	}
+	var shas []string
+	for _, e := range data.Events {
+		if e.Kind == EventKindCommit {
+			shas = append(shas, e.SHA)
+		}
+	}
+	if !slices.Equal(shas, []string{"c1"}) {
+		t.Errorf("commit SHAs = %q, want the REST page", shas)
+	}
+}
+
+func TestGraphQLEventKinds(t *testing.T) {
+	var mu sync.Mutex
+	var requests []string
+	var variables map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if r.URL.Path != "/graphql" {
+			http.NotFound(w, r)
+			return
+		}
+		var body struct {
+			Variables map[string]any `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		variables = body.Variables
+		// Answer in full, with an overflowing commit list that must not be fetched over REST.
+		if _, err := w.Write([]byte(strings.Replace(graphQLPullRequestResponse, "commitsHasNextPage", "true", 1))); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server, WithGraphQLEventKinds(EventKindReview))
+	data, err := client.PullRequest(context.Background(), "owner", "repo", 1)
+	if err != nil {
+		t.Fatalf("PullRequest() error = %v", err)
+	}
+
+	if want := []string{"POST /graphql"}; !slices.Equal(requests, want) {
+		t.Errorf("requests = %q, want %q", requests, want)
+	}
+	for _, name := range []string{"commits", "comments", "reviewComments", "statuses", "checkRuns", "timeline"} {
+		if variables[name] != false {
+			t.Errorf("variable %s = %v, want false", name, variables[name])
+		}
+	}
+	if variables["reviews"] != true {
+		t.Errorf("variable reviews = %v, want true", variables["reviews"])
+	}
+
+	var kinds []string
+	for _, e := range data.Events {
+		kinds = append(kinds, e.Kind)
+	}
+	if want := []string{"pr_opened", "review", "pr_merged"}; !slices.Equal(kinds, want) {
+		t.Errorf("kinds = %q, want %q", kinds, want)
+	}
+	if data.PullRequest.HeadSHA != "head1" {
+		t.Errorf("HeadSHA = %q, want the pull request fields in full", data.PullRequest.HeadSHA)
+	}
+}
+
+func TestGraphQLItemTypes(t *testing.T) {
+	tests := []struct {
+		kinds []string
+		want  []string
+	}{
+		{kinds: []string{EventKindReview}, want: nil},
+		{kinds: []string{EventKindLabeled, EventKindHeadRefForcePushed}, want: []string{"HEAD_REF_FORCE_PUSHED_EVENT", "LABELED_EVENT"}},
+	}
+	for _, tt := range tests {
+		c := NewClient("", WithGraphQLEventKinds(tt.kinds...))
+		if got := c.graphQLItemTypes(); !slices.Equal(got, tt.want) {
+			t.Errorf("graphQLItemTypes() for %q = %q, want %q", tt.kinds, got, tt.want)
+		}
+	}
+	if got := NewClient("", WithGraphQL()).graphQLItemTypes(); len(got) != len(gqlTimelineKinds) || !slices.Contains(got, "RENAMED_TITLE_EVENT") {
+		t.Errorf("graphQLItemTypes() without a selection = %q, want every timeline type", got)
+	}
+}
+
+func TestGraphQLNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := `{"data": {"repository": {"pullRequest": null}}, "errors": [{"type": "NOT_FOUND", "message": "Could not resolve to a PullRequest with the number of 9."}]}`
+		if _, err := w.Write([]byte(body)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server, WithGraphQL())
+	if _, err := client.PullRequest(context.Background(), "owner", "repo", 9); !IsNotFound(err) {
+		t.Errorf("PullRequest() error = %v, want not found", err)
+	}
+}
+
+func TestGraphQLURL(t *testing.T) {
+	tests := []struct {
+		api  string
+		want string
+	}{
+		{api: "https://api.github.com", want: "https://api.github.com/graphql"},
+		{api: "https://github.example.com/api/v3", want: "https://github.example.com/api/graphql"},
+	}
+	for _, tt := range tests {
+		if got := graphQLURL(tt.api); got != tt.want {
+			t.Errorf("graphQLURL(%q) = %q, want %q", tt.api, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package prx
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+)
+
+// defaultLinkedIssueLimit bounds how many linked issues WithLinkedIssues fetches.
+const defaultLinkedIssueLimit = 3
+
+// closingReference matches GitHub's closing keywords followed by a same-repository
+// issue reference, e.g. "Fixes #123" or "resolves: #45".
+var closingReference = regexp.MustCompile(`(?i)\b(?:close[sd]?|fix(?:e[sd])?|resolve[sd]?):?\s+#(\d+)\b`)
+
+// closingIssues returns the issue numbers body says it closes, in order of first
+// mention, skipping self and stopping after limit issues.
+func closingIssues(body string, self, limit int) []int {
+	var issues []int
+	for _, m := range closingReference.FindAllStringSubmatch(body, -1) {
+		if len(issues) == limit {
+			break
+		}
+		n, err := strconv.Atoi(m[1])
+		if err != nil || n == self || n <= 0 {
+			continue
+		}
+		if !slices.Contains(issues, n) {
+			issues = append(issues, n)
+		}
+	}
+	return issues
+}
+
+// linkedIssueSources returns a source per issue the pull request closes, fetching
+// that issue's comments and timeline with each event's Source set to the issue number.
+// It returns nil unless WithLinkedIssues is set. Linked issues are never cached.
+func (c *Client) linkedIssueSources(ctx context.Context, owner, repo string, prNumber int, body string) []source {
+	if c.linkedIssueLimit == 0 {
+		return nil
+	}
+	issues := closingIssues(body, prNumber, c.linkedIssueLimit)
+	if len(issues) == 0 {
+		return nil
+	}
+	c.logger.DebugContext(ctx, "fetching linked issues", "pr", prNumber, "issues", issues)
+
+	sources := make([]source, 0, len(issues))
+	for _, issue := range issues {
+		sources = append(sources, source{
+			name: fmt.Sprintf("linked issue #%d", issue),
+			fetch: func() ([]Event, error) {
+				// Streamed events are tagged on the way through, like the returned ones below.
+				issueCtx := ctx
+				if streaming(ctx) {
+					issueCtx = withEventSink(ctx, func(e Event) {
+						e.Source = issue
+						emit(ctx, e)
+					})
+				}
+				comments, err := c.comments(issueCtx, owner, repo, issue)
+				if err != nil {
+					return nil, err
+				}
+				timeline, err := c.timelineEvents(issueCtx, owner, repo, issue)
+				if err != nil {
+					return nil, err
+				}
+				events := append(comments, timeline...)
+				for i := range events {
+					events[i].Source = issue
+				}
+				return events, nil
+			},
+		})
+	}
+	return sources
+}
@@ -0,0 +1,99 @@
+package prx
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestClosingIssues(t *testing.T) {
+	tests := []struct {
+		body  string
+		limit int
+		want  []int
+	}{
+		{"Fixes #12", 3, []int{12}},
+		{"closes #3, resolves: #4 and fixed #3 again", 3, []int{3, 4}},
+		{"Refs #9, see #10", 3, nil},
+		{"Fixes #1 fixes #2 fixes #3 fixes #4", 2, []int{1, 2}},
+		{"Fixes #7", 3, nil}, // the pull request itself
+		{"Fixes other/repo#12", 3, nil},
+	}
+
+	for _, tt := range tests {
+		if got := closingIssues(tt.body, 7, tt.limit); !slices.Equal(got, tt.want) {
+			t.Errorf("closingIssues(%q) = %v, want %v", tt.body, got, tt.want)
+		}
+	}
+}
+
+func TestLinkedIssues(t *testing.T) {
+	now := time.Now()
+	mock := &mockGithubClient{
+		responses: map[string]any{
+			"/repos/owner/repo/pulls/7": githubPullRequest{
+				Number:    7,
+				Body:      "This fixes #5.",
+				State:     "open",
+				CreatedAt: githubTime{Time: now.Add(-time.Hour)},
+				User:      &githubUser{Login: "author"},
+			},
+			"/repos/owner/repo/issues/7/comments?page=1&per_page=100": []*githubComment{
+				{User: &githubUser{Login: "reviewer"}, Body: "on the PR", CreatedAt: githubTime{Time: now.Add(-30 * time.Minute)}},
+			},
+			"/repos/owner/repo/issues/5/comments?page=1&per_page=100": []*githubComment{
+				{User: &githubUser{Login: "reporter"}, Body: "on the issue", CreatedAt: githubTime{Time: now.Add(-2 * time.Hour)}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name string
+		opts []Option
+		want map[string]int // comment body -> Source
+	}{
+		{
+			name: "disabled",
+			want: map[string]int{"on the PR": 0},
+		},
+		{
+			name: "enabled",
+			opts: []Option{WithLinkedIssues(0)},
+			want: map[string]int{"on the PR": 0, "on the issue": 5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &Client{
+				github:          mock,
+				logger:          slog.Default(),
+				permissionCache: &permissionCache{memory: make(map[string]permissionEntry)},
+			}
+			for _, opt := range tt.opts {
+				opt(client)
+			}
+
+			data, err := client.PullRequest(context.Background(), "owner", "repo", 7)
+			if err != nil {
+				t.Fatalf("PullRequest() error = %v", err)
+			}
+			got := make(map[string]int)
+			for _, e := range data.Events {
+				if e.Kind == "comment" {
+					got[e.Body] = e.Source
+				}
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("comments = %v, want %v", got, tt.want)
+			}
+			for body, source := range tt.want {
+				if s, ok := got[body]; !ok || s != source {
+					t.Errorf("comment %q source = %d (present %v), want %d", body, s, ok, source)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package prx
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// MergeReadiness summarizes whether a pull request is ready to merge.
+type MergeReadiness struct {
+	Mergeable       bool     `json:"mergeable"`                  // No conflicts and GitHub has finished computing mergeability
+	ChecksPassing   bool     `json:"checks_passing"`             // The combined commit status is green and no check run failed or is pending
+	HasApproval     bool     `json:"has_approval"`               // At least one reviewer with write access last approved
+	BlockingReasons []string `json:"blocking_reasons,omitempty"` // Human-readable reasons the PR cannot merge yet
+}
+
+// MergeReadiness reports whether a pull request is ready to merge using only the
+// pull request, its head commit's combined status and check runs, and its reviews,
+// without fetching the full timeline. It is intended for merge-gating bots.
+//
+// Every status and check run counts, not just those branch protection requires:
+// required contexts are not consulted. Only the latest attempt of each check run
+// counts, as with WithLatestCheckRuns.
+//
+// As in branch protection, only reviews from users with write access approve or
+// block the pull request. Members whose access could not be confirmed
+// (WriteAccessLikely) count as having it. An approval from a reviewer whose lookup
+// failed or was skipped (WriteAccessUnknown) is reported as unverified rather than
+// missing.
+func (c *Client) MergeReadiness(ctx context.Context, owner, repo string, prNumber int) (*MergeReadiness, error) {
+	c.logger.InfoContext(ctx, "checking merge readiness", "owner", owner, "repo", repo, "pr", prNumber)
+
+	pr, err := c.pullRequest(ctx, owner, repo, prNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	var status githubCombinedStatus
+	var runs []*githubCheckRun
+	if pr.Head.SHA != "" {
+		path := fmt.Sprintf("/repos/%s/%s/commits/%s/status", owner, repo, pr.Head.SHA)
+		if _, err := c.github.get(ctx, path, &status); err != nil {
+			return nil, fmt.Errorf("fetching combined status: %w", err)
+		}
+		err := c.eachCheckRun(ctx, owner, repo, pr.Head.SHA, func(run *githubCheckRun) {
+			runs = append(runs, run)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("fetching check runs: %w", err)
+		}
+		runs = latestCheckRuns(runs)
+	}
+
+	// Latest decisive review per reviewer; a later plain comment does not undo an approval.
+	verdicts := make(map[string]*githubReview)
+	var reviewers []string
+	path := fmt.Sprintf("/repos/%s/%s/pulls/%d/reviews", owner, repo, prNumber)
+	err = paginate(ctx, c, path, func(review *githubReview) error {
+		state := strings.ToUpper(review.State)
+		if review.User == nil || (state != "APPROVED" && state != "CHANGES_REQUESTED" && state != "DISMISSED") {
+			return nil
+		}
+		if _, seen := verdicts[review.User.Login]; !seen {
+			reviewers = append(reviewers, review.User.Login)
+		}
+		verdicts[review.User.Login] = review
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetching reviews: %w", err)
+	}
+
+	// GitHub reports "pending" for commits with no statuses at all.
+	statusesPassing := status.State == "success" || status.TotalCount == 0
+	var runProblems []string
+	for _, run := range runs {
+		switch {
+		case run.Status != "completed":
+			runProblems = append(runProblems, fmt.Sprintf("check run %s is %s", run.Name, run.Status))
+		case !slices.Contains(passingConclusions, run.Conclusion):
+			runProblems = append(runProblems, fmt.Sprintf("check run %s is %s", run.Name, run.Conclusion))
+		}
+	}
+	r := &MergeReadiness{
+		Mergeable:     pr.Mergeable != nil && *pr.Mergeable,
+		ChecksPassing: statusesPassing && len(runProblems) == 0,
+	}
+
+	if pr.State != "open" {
+		r.BlockingReasons = append(r.BlockingReasons, "pull request is "+pr.State)
+	}
+	if pr.Draft {
+		r.BlockingReasons = append(r.BlockingReasons, "pull request is a draft")
+	}
+	switch {
+	case pr.Mergeable == nil:
+		r.BlockingReasons = append(r.BlockingReasons, "mergeability is still being computed")
+	case !*pr.Mergeable:
+		r.BlockingReasons = append(r.BlockingReasons, "pull request has merge conflicts")
+	case pr.MergeableState == "blocked":
+		r.BlockingReasons = append(r.BlockingReasons, "blocked by branch protection")
+	case pr.MergeableState == "behind":
+		r.BlockingReasons = append(r.BlockingReasons, "head branch is behind the base branch")
+	}
+	if !statusesPassing {
+		r.BlockingReasons = append(r.BlockingReasons, "status checks are "+status.State)
+	}
+	r.BlockingReasons = append(r.BlockingReasons, runProblems...)
+	var unverified []string
+	for _, reviewer := range reviewers {
+		review := verdicts[reviewer]
+		state := strings.ToUpper(review.State)
+		if state == "DISMISSED" {
+			continue
+		}
+		switch c.writeAccess(ctx, owner, repo, review.User, review.AuthorAssociation) {
+		case WriteAccessDefinitely, WriteAccessLikely:
+		case WriteAccessUnknown:
+			if state == "APPROVED" {
+				unverified = append(unverified, reviewer)
+			}
+			continue
+		default:
+			c.logger.DebugContext(ctx, "ignoring review from user without write access", "reviewer", reviewer, "state", state)
+			continue
+		}
+		switch state {
+		case "APPROVED":
+			r.HasApproval = true
+		case "CHANGES_REQUESTED":
+			r.BlockingReasons = append(r.BlockingReasons, "changes requested by "+reviewer)
+		}
+	}
+	switch {
+	case r.HasApproval:
+	case len(unverified) > 0:
+		r.BlockingReasons = append(r.BlockingReasons, "approval by "+strings.Join(unverified, ", ")+" is unverified")
+	default:
+		r.BlockingReasons = append(r.BlockingReasons, "no approving review")
+	}
+
+	c.logger.InfoContext(ctx, "merge readiness",
+		"pr", prNumber,
+		"mergeable", r.Mergeable,
+		"checks_passing", r.ChecksPassing,
+		"approved", r.HasApproval,
+		"blocking_reasons", r.BlockingReasons)
+	return r, nil
+}
+
+// passingConclusions are the check run conclusions that do not block a merge.
+var passingConclusions = []string{"success", "neutral", "skipped"}
+
+// Merge methods reported by MergeMethod.
+const (
+	MergeMethodMerge   = "merge"
+	MergeMethodSquash  = "squash"
+	MergeMethodRebase  = "rebase"
+	MergeMethodUnknown = "unknown"
+)
+
+// MergeMethod infers how a merged pull request was merged. GitHub does not record
+// this, so the answer is a best-effort heuristic based on the merge commit:
+//
+//   - More than one parent means a merge commit.
+//   - A single parent whose title ends in "(#N)" for this PR means a squash merge.
+//   - A single parent committed by GitHub ("web-flow") otherwise means a rebase merge.
+//
+// Anything else, including unmerged PRs and commits pushed to the base branch
+// outside GitHub's merge button, yields MergeMethodUnknown. A squash merge whose
+// title was edited to drop the PR number is reported as a rebase.
+func (c *Client) MergeMethod(ctx context.Context, owner, repo string, prNumber int) (string, error) {
+	pr, err := c.pullRequest(ctx, owner, repo, prNumber)
+	if err != nil {
+		return "", err
+	}
+	if !pr.Merged || pr.MergeCommitSHA == "" {
+		c.logger.DebugContext(ctx, "pull request has no merge commit", "pr", prNumber, "merged", pr.Merged)
+		return MergeMethodUnknown, nil
+	}
+
+	var commit githubCommitDetail
+	path := fmt.Sprintf("/repos/%s/%s/commits/%s", owner, repo, pr.MergeCommitSHA)
+	if _, err := c.github.get(ctx, path, &commit); err != nil {
+		return "", fmt.Errorf("fetching merge commit: %w", err)
+	}
+
+	title, _, _ := strings.Cut(commit.Commit.Message, "\n")
+	method := MergeMethodUnknown
+	switch {
+	case len(commit.Parents) > 1:
+		method = MergeMethodMerge
+	case len(commit.Parents) == 0:
+		// No parents reported; nothing to infer from.
+	case strings.HasSuffix(strings.TrimSpace(title), fmt.Sprintf("(#%d)", prNumber)):
+		method = MergeMethodSquash
+	case commit.Committer != nil && commit.Committer.Login == "web-flow":
+		method = MergeMethodRebase
+	}
+
+	c.logger.DebugContext(ctx, "inferred merge method",
+		"pr", prNumber,
+		"sha", pr.MergeCommitSHA,
+		"parents", len(commit.Parents),
+		"method", method)
+	return method, nil
+}
@@ -0,0 +1,221 @@
+package prx
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestMergeReadiness(t *testing.T) {
+	mergeable := true
+	pr := githubPullRequest{
+		Number:         1,
+		State:          "open",
+		Mergeable:      &mergeable,
+		MergeableState: "clean",
+		User:           &githubUser{Login: "author"},
+	}
+	pr.Head.SHA = "abc123"
+
+	alice := &githubUser{Login: "alice"}
+	approved := []*githubReview{
+		{User: alice, AuthorAssociation: "COLLABORATOR", State: "CHANGES_REQUESTED", SubmittedAt: githubTime{Time: time.Now().Add(-time.Hour)}},
+		{User: alice, AuthorAssociation: "COLLABORATOR", State: "APPROVED", SubmittedAt: githubTime{Time: time.Now().Add(-time.Minute)}},
+		{User: alice, AuthorAssociation: "COLLABORATOR", State: "COMMENTED", SubmittedAt: githubTime{Time: time.Now()}},
+	}
+	run := func(name, status, conclusion string, minutes int) *githubCheckRun {
+		started := time.Now().Add(time.Duration(minutes) * time.Minute)
+		return &githubCheckRun{Name: name, Status: status, Conclusion: conclusion, StartedAt: githubTime{Time: started}}
+	}
+
+	tests := []struct {
+		name         string
+		status       githubCombinedStatus
+		runs         []*githubCheckRun
+		reviews      []*githubReview
+		noLookup     bool
+		wantChecks   bool
+		wantApproval bool
+		wantReasons  []string
+	}{
+		{
+			name:         "green",
+			status:       githubCombinedStatus{State: "success", TotalCount: 2},
+			reviews:      approved,
+			wantChecks:   true,
+			wantApproval: true,
+		},
+		{
+			name:         "failing checks",
+			status:       githubCombinedStatus{State: "failure", TotalCount: 2},
+			reviews:      approved,
+			wantApproval: true,
+			wantReasons:  []string{"status checks are failure"},
+		},
+		{
+			name:        "no approval",
+			status:      githubCombinedStatus{State: "success", TotalCount: 1},
+			wantChecks:  true,
+			wantReasons: []string{"no approving review"},
+		},
+		{
+			name:         "failing and pending check runs",
+			runs:         []*githubCheckRun{run("lint", "completed", "failure", -5), run("test", "in_progress", "", -4), run("docs", "completed", "skipped", -3)},
+			reviews:      approved,
+			wantApproval: true,
+			wantReasons:  []string{"check run lint is failure", "check run test is in_progress"},
+		},
+		{
+			name:         "re-run check run passed",
+			runs:         []*githubCheckRun{run("test", "completed", "failure", -10), run("test", "completed", "success", -2)},
+			reviews:      approved,
+			wantChecks:   true,
+			wantApproval: true,
+		},
+		{
+			name: "reviews from users without write access",
+			reviews: []*githubReview{
+				{User: &githubUser{Login: "drive-by"}, AuthorAssociation: "CONTRIBUTOR", State: "APPROVED"},
+				{User: &githubUser{Login: "critic"}, AuthorAssociation: "NONE", State: "CHANGES_REQUESTED"},
+			},
+			wantChecks:  true,
+			wantReasons: []string{"no approving review"},
+		},
+		{
+			name: "approval from member with unconfirmed access",
+			reviews: []*githubReview{
+				{User: &githubUser{Login: "member"}, AuthorAssociation: "MEMBER", State: "APPROVED"},
+			},
+			wantChecks:   true,
+			wantApproval: true,
+		},
+		{
+			name: "approval from member with skipped lookup",
+			reviews: []*githubReview{
+				{User: &githubUser{Login: "member"}, AuthorAssociation: "MEMBER", State: "APPROVED"},
+			},
+			noLookup:    true,
+			wantChecks:  true,
+			wantReasons: []string{"approval by member is unverified"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockGithubClient{
+				responses: map[string]any{
+					"/repos/owner/repo/pulls/1":                                       pr,
+					"/repos/owner/repo/commits/abc123/status":                         tt.status,
+					"/repos/owner/repo/pulls/1/reviews?page=1&per_page=100":           tt.reviews,
+					"/repos/owner/repo/commits/abc123/check-runs?page=1&per_page=100": githubCheckRuns{CheckRuns: tt.runs},
+				},
+			}
+			// Permission lookups are forbidden, so members' access is only likely.
+			c := &Client{
+				github:              &permissionErrorClient{mockGithubClient: mock, err: &GitHubAPIError{StatusCode: http.StatusForbidden, Status: "403 Forbidden"}},
+				logger:              slog.Default(),
+				permissionCache:     &permissionCache{memory: make(map[string]permissionEntry)},
+				noWriteAccessLookup: tt.noLookup,
+			}
+
+			r, err := c.MergeReadiness(context.Background(), "owner", "repo", 1)
+			if err != nil {
+				t.Fatalf("MergeReadiness() error = %v", err)
+			}
+			if !r.Mergeable {
+				t.Error("expected Mergeable")
+			}
+			if r.ChecksPassing != tt.wantChecks {
+				t.Errorf("ChecksPassing = %v, want %v", r.ChecksPassing, tt.wantChecks)
+			}
+			if r.HasApproval != tt.wantApproval {
+				t.Errorf("HasApproval = %v, want %v", r.HasApproval, tt.wantApproval)
+			}
+			if !slices.Equal(r.BlockingReasons, tt.wantReasons) {
+				t.Errorf("BlockingReasons = %q, want %q", r.BlockingReasons, tt.wantReasons)
+			}
+			for _, call := range mock.calls {
+				if call == "/repos/owner/repo/issues/1/timeline?page=1&per_page=100" {
+					t.Error("MergeReadiness should not fetch the timeline")
+				}
+			}
+		})
+	}
+}
+
+func TestMergeMethod(t *testing.T) {
+	parents := func(n int) []githubCommitParent { return make([]githubCommitParent, n) }
+	webFlow := &githubUser{Login: "web-flow"}
+
+	tests := []struct {
+		name   string
+		merged bool
+		commit githubCommitDetail
+		want   string
+	}{
+		{
+			name:   "merge commit",
+			merged: true,
+			commit: githubCommitDetail{Commit: githubCommit{Message: "Merge pull request #7 from dev/feature"}, Committer: webFlow, Parents: parents(2)},
+			want:   MergeMethodMerge,
+		},
+		{
+			name:   "squash",
+			merged: true,
+			commit: githubCommitDetail{Commit: githubCommit{Message: "Add feature (#7)\n\n* first\n* second"}, Committer: webFlow, Parents: parents(1)},
+			want:   MergeMethodSquash,
+		},
+		{
+			name:   "squash title for another PR",
+			merged: true,
+			commit: githubCommitDetail{Commit: githubCommit{Message: "Revert \"Add feature (#6)\""}, Committer: webFlow, Parents: parents(1)},
+			want:   MergeMethodRebase,
+		},
+		{
+			name:   "rebase",
+			merged: true,
+			commit: githubCommitDetail{Commit: githubCommit{Message: "Add feature"}, Committer: webFlow, Parents: parents(1)},
+			want:   MergeMethodRebase,
+		},
+		{
+			name:   "pushed outside GitHub",
+			merged: true,
+			commit: githubCommitDetail{Commit: githubCommit{Message: "Add feature"}, Committer: &githubUser{Login: "dev"}, Parents: parents(1)},
+			want:   MergeMethodUnknown,
+		},
+		{
+			name: "not merged",
+			want: MergeMethodUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pr := githubPullRequest{Number: 7, State: "closed", Merged: tt.merged, User: &githubUser{Login: "dev"}}
+			if tt.merged {
+				pr.MergeCommitSHA = "deadbeef"
+			}
+			mock := &mockGithubClient{
+				responses: map[string]any{
+					"/repos/owner/repo/pulls/7":          pr,
+					"/repos/owner/repo/commits/deadbeef": tt.commit,
+				},
+			}
+			c := &Client{github: mock, logger: slog.Default()}
+
+			got, err := c.MergeMethod(context.Background(), "owner", "repo", 7)
+			if err != nil {
+				t.Fatalf("MergeMethod() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("MergeMethod() = %q, want %q", got, tt.want)
+			}
+			if !tt.merged && slices.Contains(mock.calls, "/repos/owner/repo/commits/deadbeef") {
+				t.Error("fetched a merge commit for an unmerged PR")
+			}
+		})
+	}
+}
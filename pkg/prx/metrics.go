@@ -0,0 +1,78 @@
+package prx
+
+import "time"
+
+// TimeToFirstReview returns how long a pull request waited for its first review
+// from a human, measured from prCreatedAt. Bot reviews and pending reviews, which
+// have no submission time, are ignored. The bool is false when no human has
+// submitted a review.
+func TimeToFirstReview(events []Event, prCreatedAt time.Time) (time.Duration, bool) {
+	var first time.Time
+	for _, e := range events {
+		if e.Kind != EventKindReview || e.Bot || e.Outcome == "pending" || e.Timestamp.IsZero() {
+			continue
+		}
+		if first.IsZero() || e.Timestamp.Before(first) {
+			first = e.Timestamp
+		}
+	}
+	if first.IsZero() {
+		return 0, false
+	}
+	return first.Sub(prCreatedAt), true
+}
+
+// TimeToMerge returns how long a pull request took to merge, measured from prCreatedAt,
+// using the merged timeline event or the pr_merged event PullRequest adds. The bool is
+// false for pull requests that are still open or were closed without merging.
+func TimeToMerge(events []Event, prCreatedAt time.Time) (time.Duration, bool) {
+	for _, e := range events {
+		if e.Kind == EventKindMerged || e.Kind == EventKindPRMerged {
+			return e.Timestamp.Sub(prCreatedAt), true
+		}
+	}
+	return 0, false
+}
+
+// ReviewCounts tallies where each human reviewer stands on a pull request.
+type ReviewCounts struct {
+	Approved         int `json:"approved"`
+	ChangesRequested int `json:"changes_requested"`
+	Commented        int `json:"commented"` // Reviewers who have only commented
+}
+
+// ReviewSummary counts each human reviewer once, by their latest review. As on GitHub,
+// a later comment-only review does not undo an approval or change request, so a reviewer
+// who requested changes, approved, then commented counts as approved. Bot reviews are ignored.
+func ReviewSummary(events []Event) ReviewCounts {
+	decisions := make(map[string]Event)
+	commented := make(map[string]bool)
+	for _, e := range events {
+		if e.Kind != EventKindReview || e.Bot || e.Actor == "" {
+			continue
+		}
+		switch e.Outcome {
+		case "commented":
+			commented[e.Actor] = true
+		case "approved", "changes_requested":
+			if prev, ok := decisions[e.Actor]; !ok || !e.Timestamp.Before(prev.Timestamp) {
+				decisions[e.Actor] = e
+			}
+		}
+	}
+
+	var counts ReviewCounts
+	for _, e := range decisions {
+		if e.Outcome == "approved" {
+			counts.Approved++
+		} else {
+			counts.ChangesRequested++
+		}
+	}
+	for actor := range commented {
+		if _, ok := decisions[actor]; !ok {
+			counts.Commented++
+		}
+	}
+	return counts
+}
@@ -0,0 +1,220 @@
+package prx
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestTimeToFirstReview(t *testing.T) {
+	created := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) time.Time { return created.Add(d) }
+
+	tests := []struct {
+		name   string
+		events []Event
+		want   time.Duration
+		wantOK bool
+	}{
+		{
+			name:   "no reviews",
+			events: []Event{{Kind: EventKindComment, Actor: "alice", Timestamp: at(time.Hour)}},
+		},
+		{
+			name: "only bot reviews",
+			events: []Event{
+				{Kind: EventKindReview, Actor: "copilot[bot]", Bot: true, Timestamp: at(time.Minute)},
+			},
+		},
+		{
+			name: "earliest human review wins regardless of order",
+			events: []Event{
+				{Kind: EventKindReview, Actor: "bob", Timestamp: at(3 * time.Hour)},
+				{Kind: EventKindReview, Actor: "linter[bot]", Bot: true, Timestamp: at(time.Minute)},
+				{Kind: EventKindReviewComment, Actor: "carol", Timestamp: at(time.Hour)},
+				{Kind: EventKindReview, Actor: "carol", Timestamp: at(2 * time.Hour)},
+			},
+			want:   2 * time.Hour,
+			wantOK: true,
+		},
+		{
+			name: "pending review after a submitted one",
+			events: []Event{
+				{Kind: EventKindReview, Actor: "bob", Outcome: "approved", Timestamp: at(time.Hour)},
+				{Kind: EventKindReview, Actor: "carol", Outcome: "pending"},
+			},
+			want:   time.Hour,
+			wantOK: true,
+		},
+		{
+			name: "only a pending review",
+			events: []Event{
+				{Kind: EventKindReview, Actor: "carol", Outcome: "pending"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := TimeToFirstReview(tt.events, created)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("TimeToFirstReview() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestTimeToMerge(t *testing.T) {
+	created := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) time.Time { return created.Add(d) }
+
+	tests := []struct {
+		name   string
+		events []Event
+		want   time.Duration
+		wantOK bool
+	}{
+		{
+			name:   "still open",
+			events: []Event{{Kind: EventKindReview, Actor: "bob", Timestamp: at(time.Hour)}},
+		},
+		{
+			name: "closed without merging",
+			events: []Event{
+				{Kind: EventKindClosed, Actor: "bob", Timestamp: at(time.Hour)},
+				{Kind: "pr_closed", Actor: "bob", Timestamp: at(time.Hour)},
+			},
+		},
+		{
+			name: "merged timeline event",
+			events: []Event{
+				{Kind: EventKindReview, Actor: "bob", Timestamp: at(time.Hour)},
+				{Kind: EventKindMerged, Actor: "bob", Timestamp: at(26 * time.Hour)},
+				{Kind: EventKindClosed, Actor: "bob", Timestamp: at(26 * time.Hour)},
+			},
+			want:   26 * time.Hour,
+			wantOK: true,
+		},
+		{
+			name:   "pr_merged event",
+			events: []Event{{Kind: EventKindPRMerged, Actor: "bob", Timestamp: at(90 * time.Minute)}},
+			want:   90 * time.Minute,
+			wantOK: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := TimeToMerge(tt.events, created)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("TimeToMerge() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestReviewSummary(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	review := func(actor, outcome string, minutes int) Event {
+		return Event{Kind: EventKindReview, Actor: actor, Outcome: outcome, Timestamp: t0.Add(time.Duration(minutes) * time.Minute)}
+	}
+
+	tests := []struct {
+		name   string
+		events []Event
+		want   ReviewCounts
+	}{
+		{name: "no reviews"},
+		{
+			name: "changes requested then approved",
+			events: []Event{
+				review("alice", "changes_requested", 1),
+				review("alice", "approved", 5),
+			},
+			want: ReviewCounts{Approved: 1},
+		},
+		{
+			name: "approved then changed mind, given out of order",
+			events: []Event{
+				review("bob", "changes_requested", 9),
+				review("bob", "approved", 2),
+			},
+			want: ReviewCounts{ChangesRequested: 1},
+		},
+		{
+			name: "comment after approval keeps the approval",
+			events: []Event{
+				review("alice", "approved", 1),
+				review("alice", "commented", 2),
+				review("carol", "commented", 3),
+				review("carol", "commented", 4),
+			},
+			want: ReviewCounts{Approved: 1, Commented: 1},
+		},
+		{
+			name: "bots and other events ignored",
+			events: []Event{
+				{Kind: EventKindReview, Actor: "ci[bot]", Bot: true, Outcome: "changes_requested", Timestamp: t0},
+				{Kind: EventKindComment, Actor: "dave", Outcome: "approved", Timestamp: t0},
+				review("erin", "approved", 1),
+			},
+			want: ReviewCounts{Approved: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ReviewSummary(tt.events); got != tt.want {
+				t.Errorf("ReviewSummary() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReviewSummaryFromAPI(t *testing.T) {
+	base := time.Now().Add(-time.Hour)
+	at := func(minutes int) githubTime { return githubTime{Time: base.Add(time.Duration(minutes) * time.Minute)} }
+	mock := &mockGithubClient{
+		responses: map[string]any{
+			"/repos/owner/repo/pulls/1": githubPullRequest{
+				Number: 1, State: "open", CreatedAt: at(0), User: &githubUser{Login: "author"}, Head: githubRef{SHA: "abc123"},
+			},
+			// The API reports submitted reviews in upper case.
+			"/repos/owner/repo/pulls/1/reviews?page=1&per_page=100": []*githubReview{
+				{ID: 1, User: &githubUser{Login: "alice"}, State: "CHANGES_REQUESTED", SubmittedAt: at(1)},
+				{ID: 2, User: &githubUser{Login: "alice"}, State: "APPROVED", SubmittedAt: at(2)},
+				{ID: 3, User: &githubUser{Login: "bob"}, State: "CHANGES_REQUESTED", SubmittedAt: at(3)},
+				{ID: 4, User: &githubUser{Login: "carol"}, State: "COMMENTED", SubmittedAt: at(4)},
+			},
+			// The timeline reports dismissed reviews in lower case.
+			"/repos/owner/repo/issues/1/timeline?page=1&per_page=100": []map[string]any{
+				{
+					"event": "review_dismissed", "created_at": at(5), "actor": map[string]any{"login": "author"},
+					"dismissed_review": map[string]any{"state": "changes_requested", "review_id": 3},
+				},
+			},
+		},
+	}
+	client := &Client{
+		github:          mock,
+		logger:          slog.Default(),
+		permissionCache: &permissionCache{memory: make(map[string]permissionEntry)},
+	}
+
+	events, err := client.PullRequestEvents(context.Background(), "owner", "repo", 1)
+	if err != nil {
+		t.Fatalf("PullRequestEvents() error = %v", err)
+	}
+	var outcomes []string
+	for _, e := range events {
+		if e.Kind == EventKindReview || e.Kind == EventKindReviewDismissed {
+			outcomes = append(outcomes, e.Kind+":"+e.Outcome)
+		}
+	}
+	want := []string{"review:changes_requested", "review:approved", "review:changes_requested", "review:commented", "review_dismissed:changes_requested"}
+	if !slices.Equal(outcomes, want) {
+		t.Errorf("outcomes = %q, want %q", outcomes, want)
+	}
+	if got, want := ReviewSummary(events), (ReviewCounts{Approved: 1, ChangesRequested: 1, Commented: 1}); got != want {
+		t.Errorf("ReviewSummary() = %+v, want %+v", got, want)
+	}
+}
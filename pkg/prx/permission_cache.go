@@ -19,6 +19,7 @@ type permissionCache struct {
 	mu       sync.RWMutex
 	memory   map[string]permissionEntry
 	diskPath string
+	ttl      time.Duration // How long entries stay fresh; 0 means permissionCacheDuration
 }
 
 // permissionEntry represents a cached permission.
@@ -27,11 +28,13 @@ type permissionEntry struct {
 	CachedAt   time.Time `json:"cached_at"`
 }
 
-// newPermissionCache creates a new permission cache.
-func newPermissionCache(cacheDir string) (*permissionCache, error) {
+// newPermissionCache creates a new permission cache whose entries expire after ttl
+// (permissionCacheDuration if ttl is 0).
+func newPermissionCache(cacheDir string, ttl time.Duration) (*permissionCache, error) {
 	pc := &permissionCache{
 		memory:   make(map[string]permissionEntry),
 		diskPath: filepath.Join(cacheDir, permissionCacheFile),
+		ttl:      ttl,
 	}
 
 	// Load existing cache from disk
@@ -57,13 +60,21 @@ func (pc *permissionCache) get(owner, repo, username string) (string, bool) {
 	}
 
 	// Check if cache entry is expired
-	if time.Since(entry.CachedAt) > permissionCacheDuration {
+	if time.Since(entry.CachedAt) > pc.expiry() {
 		return "", false
 	}
 
 	return entry.Permission, true
 }
 
+// expiry returns how long entries stay fresh.
+func (pc *permissionCache) expiry() time.Duration {
+	if pc.ttl > 0 {
+		return pc.ttl
+	}
+	return permissionCacheDuration
+}
+
 // set stores a permission in the cache.
 func (pc *permissionCache) set(owner, repo, username, permission string) error {
 	key := fmt.Sprintf("%s/%s/%s", owner, repo, username)
@@ -79,6 +90,22 @@ func (pc *permissionCache) set(owner, repo, username, permission string) error {
 	return pc.saveToDisk()
 }
 
+// setAll stores permissions for many users of one repository, keyed by login,
+// writing the cache to disk once.
+func (pc *permissionCache) setAll(owner, repo string, permissions map[string]string) error {
+	now := time.Now()
+	pc.mu.Lock()
+	for username, permission := range permissions {
+		pc.memory[fmt.Sprintf("%s/%s/%s", owner, repo, username)] = permissionEntry{
+			Permission: permission,
+			CachedAt:   now,
+		}
+	}
+	pc.mu.Unlock()
+
+	return pc.saveToDisk()
+}
+
 // loadFromDisk loads the cache from disk.
 func (pc *permissionCache) loadFromDisk() error {
 	// Skip if no disk path is set (in-memory only mode)
@@ -104,7 +131,7 @@ func (pc *permissionCache) loadFromDisk() error {
 
 	// Only load non-expired entries
 	for key, entry := range cache {
-		if time.Since(entry.CachedAt) <= permissionCacheDuration {
+		if time.Since(entry.CachedAt) <= pc.expiry() {
 			pc.memory[key] = entry
 		}
 	}
@@ -149,7 +176,7 @@ func (pc *permissionCache) saveToDisk() error {
 func (pc *permissionCache) cleanup() error {
 	pc.mu.Lock()
 	for key, entry := range pc.memory {
-		if time.Since(entry.CachedAt) > permissionCacheDuration {
+		if time.Since(entry.CachedAt) > pc.expiry() {
 			delete(pc.memory, key)
 		}
 	}
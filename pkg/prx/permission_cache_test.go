@@ -1,8 +1,10 @@
 package prx
 
 import (
+	"context"
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
 	"time"
 )
@@ -104,4 +106,48 @@ func TestPermissionCache(t *testing.T) {
 			t.Error("expected expired entry to not be found")
 		}
 	})
+	t.Run("custom ttl", func(t *testing.T) {
+		cache := &permissionCache{
+			memory: make(map[string]permissionEntry),
+			ttl:    time.Minute,
+		}
+		cache.memory["owner/repo/user1"] = permissionEntry{Permission: "write", CachedAt: time.Now().Add(-30 * time.Second)}
+		cache.memory["owner/repo/user2"] = permissionEntry{Permission: "write", CachedAt: time.Now().Add(-2 * time.Minute)}
+
+		if _, found := cache.get("owner", "repo", "user1"); !found {
+			t.Error("expected entry within ttl to be found")
+		}
+		if _, found := cache.get("owner", "repo", "user2"); found {
+			t.Error("expected entry past ttl to not be found")
+		}
+	})
+}
+
+func TestPermissionLookupsCachedPerUser(t *testing.T) {
+	mock := &mockGithubClient{responses: map[string]any{
+		"/repos/owner/repo/collaborators/alice/permission": "write",
+	}}
+	client := NewClient("token", WithPermissionCacheTTL(time.Hour))
+	client.github = mock
+
+	ctx := context.Background()
+	for range 3 {
+		for _, login := range []string{"alice", "bob"} {
+			client.writeAccess(ctx, "owner", "repo", &githubUser{Login: login}, "MEMBER")
+		}
+	}
+	if got := client.writeAccess(ctx, "owner", "repo", &githubUser{Login: "alice"}, "MEMBER"); got != WriteAccessDefinitely {
+		t.Errorf("writeAccess(alice) = %d, want %d", got, WriteAccessDefinitely)
+	}
+
+	want := []string{
+		"/repos/owner/repo/collaborators/alice/permission",
+		"/repos/owner/repo/collaborators/bob/permission",
+	}
+	if !slices.Equal(mock.calls, want) {
+		t.Errorf("permission API calls = %q, want %q", mock.calls, want)
+	}
+	if client.permissionCache.ttl != time.Hour {
+		t.Errorf("ttl = %v, want 1h", client.permissionCache.ttl)
+	}
 }
@@ -9,7 +9,7 @@ type PullRequest struct {
 	// Basic Information
 	Number int    `json:"number"` // PR number (e.g., 1773)
 	Title  string `json:"title"`  // PR title
-	Body   string `json:"body"`   // PR description (truncated to 256 chars)
+	Body   string `json:"body"`   // PR description, cut to WithBodyLimit bytes (256 by default)
 	Author string `json:"author"` // GitHub username of the PR author
 
 	// Status Information
@@ -26,6 +26,16 @@ type PullRequest struct {
 	ClosedAt  *time.Time `json:"closed_at,omitempty"` // When the PR was closed (nil if still open)
 	MergedAt  *time.Time `json:"merged_at,omitempty"` // When the PR was merged (nil if not merged)
 
+	// Commits
+	HeadSHA string `json:"head_sha,omitempty"` // Commit at the tip of the PR branch
+	BaseSHA string `json:"base_sha,omitempty"` // Commit on the base branch the PR was compared against
+
+	// Head repository. For PRs from forks, HeadSHA lives in HeadRepo rather than the
+	// base repository; GitHub still reports the PR's CI statuses and check runs on
+	// the base repository, but other commit lookups may need to go to HeadRepo.
+	HeadRepo string `json:"head_repo,omitempty"` // "owner/repo" the PR branch lives in; empty if the fork was deleted
+	IsFork   bool   `json:"is_fork,omitempty"`   // True if the head repository differs from the base repository
+
 	// Code Changes
 	Additions    int `json:"additions"`     // Total lines added
 	Deletions    int `json:"deletions"`     // Total lines removed
@@ -35,11 +45,16 @@ type PullRequest struct {
 	AuthorBot          bool     `json:"author_bot"`                    // True if author is a bot account
 	AuthorWriteAccess  int      `json:"author_write_access,omitempty"` // Author's repository permissions (-2 to 2, same as Event.WriteAccess)
 	Assignees          []string `json:"assignees,omitempty"`           // Current assignees
-	RequestedReviewers []string `json:"requested_reviewers,omitempty"` // Pending review requests
+	RequestedReviewers []string `json:"requested_reviewers,omitempty"` // Pending review requests from individuals
+	RequestedTeams     []string `json:"requested_teams,omitempty"`     // Pending review requests from teams, by name
 
 	// Organization
 	Labels []string `json:"labels,omitempty"` // Applied labels
 
+	// DependencyBump is set when the PR was recognized as an automated version bump
+	// and its events were compacted (see WithDependencyBumpCompaction).
+	DependencyBump bool `json:"dependency_bump,omitempty"`
+
 	// Aggregated Summaries (computed from events)
 	TestSummary     *TestSummary     `json:"test_summary,omitempty"`     // Test results summary
 	StatusSummary   *StatusSummary   `json:"status_summary,omitempty"`   // All checks summary
@@ -76,5 +91,5 @@ type ApprovalSummary struct {
 // PullRequestData contains a pull request and all its associated events.
 type PullRequestData struct {
 	PullRequest PullRequest `json:"pull_request"`
-	Events      []Event     `json:"events"`
+	Events      Timeline    `json:"events"`
 }
@@ -95,6 +95,76 @@ func TestContainsQuestion(t *testing.T) {
 			input:    "Is it possible to add caching here",
 			expected: true,
 		},
+		{
+			name:     "question mark only in fenced code",
+			input:    "Simplified this:\n```go\nx := a ? b : c\n```\nLooks good now.",
+			expected: false,
+		},
+		{
+			name:     "question mark only in tilde fence",
+			input:    "Updated the pattern.\n~~~\n^https?://\n~~~",
+			expected: false,
+		},
+		{
+			name:     "question mark only in inline code",
+			input:    "Changed the regex to `colou?r` and `\\d+?`.",
+			expected: false,
+		},
+		{
+			name:     "truncated open fence",
+			input:    "Done.\n```js\nconst y = ok ? 1 : 2",
+			expected: false,
+		},
+		{
+			name:     "question outside code",
+			input:    "Why `a ? b : c` here instead of an if?",
+			expected: true,
+		},
+		{
+			name:     "question after fence",
+			input:    "```\nfoo?\n```\nDoes this still compile?",
+			expected: true,
+		},
+		{
+			name:     "reply quoting a question",
+			input:    "> Should we cache this?\n\nYes, done in the latest commit.",
+			expected: false,
+		},
+		{
+			name:     "nested quote of a question",
+			input:    "> > can you rename it\n> sure\nRenamed.",
+			expected: false,
+		},
+		{
+			name:     "quote plus a new question",
+			input:    "> Should we cache this?\n\nDone. Is a 5 minute TTL enough?",
+			expected: true,
+		},
+		{
+			name:     "greater-than inside a line is not a quote",
+			input:    "if n > 0 should we bail?",
+			expected: true,
+		},
+		{
+			name:     "japanese full-width question mark",
+			input:    "このテストは必要ですか？",
+			expected: true,
+		},
+		{
+			name:     "chinese full-width question mark",
+			input:    "为什么要改这个函数？",
+			expected: true,
+		},
+		{
+			name:     "spanish opening question mark",
+			input:    "¿Podemos fusionar esto hoy",
+			expected: true,
+		},
+		{
+			name:     "japanese statement",
+			input:    "修正しました。",
+			expected: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,93 @@
+package prx
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// rateState tracks the most recent rate limit GitHub reported.
+type rateState struct {
+	mu        sync.Mutex
+	known     bool
+	remaining int
+	reset     time.Time
+}
+
+// parseRateLimit reads the X-RateLimit-Remaining and X-RateLimit-Reset headers.
+// ok is false unless both are present and valid.
+func parseRateLimit(h http.Header) (remaining int, reset time.Time, ok bool) {
+	remaining, err := strconv.Atoi(h.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return 0, time.Time{}, false
+	}
+	resetUnix, err := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return 0, time.Time{}, false
+	}
+	return remaining, time.Unix(resetUnix, 0), true
+}
+
+// update records the rate limit headers of a response, if present.
+func (r *rateState) update(h http.Header) {
+	remaining, reset, ok := parseRateLimit(h)
+	if !ok {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.known = true
+	r.remaining = remaining
+	r.reset = reset
+}
+
+// RateLimit returns the requests remaining and when the limit resets, as reported
+// by the most recent API response. remaining is -1 until a response reports it.
+func (c *githubClient) RateLimit() (remaining int, reset time.Time) {
+	c.rate.mu.Lock()
+	defer c.rate.mu.Unlock()
+	if !c.rate.known {
+		return -1, time.Time{}
+	}
+	return c.rate.remaining, c.rate.reset
+}
+
+// waitForRateLimit blocks until the rate limit resets when the last response said
+// no requests remain, so a doomed request is not sent. It fails immediately if
+// ctx would expire before the reset.
+func (c *githubClient) waitForRateLimit(ctx context.Context) error {
+	remaining, reset := c.RateLimit()
+	if remaining != 0 {
+		return nil
+	}
+	// The reset header has one-second resolution; wait a moment past it.
+	wait := time.Until(reset) + time.Second
+	if wait <= time.Second {
+		return nil
+	}
+	if deadline, ok := ctx.Deadline(); ok && deadline.Before(reset) {
+		return fmt.Errorf("rate limit exhausted until %s, after the context deadline", reset.Format(time.RFC3339))
+	}
+
+	c.logger.WarnContext(ctx, "GitHub rate limit exhausted, waiting for reset", "reset", reset, "wait", wait)
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// RateLimit returns the requests remaining and when the limit resets, as reported
+// by the most recent GitHub API response. remaining is -1 until a response reports it.
+func (c *Client) RateLimit() (remaining int, reset time.Time) {
+	if gc, ok := c.github.(*githubClient); ok {
+		return gc.RateLimit()
+	}
+	return -1, time.Time{}
+}
@@ -0,0 +1,64 @@
+package prx
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimitTracking(t *testing.T) {
+	var requests atomic.Int32
+	reset := time.Now().Add(time.Second).Truncate(time.Second)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := requests.Add(1)
+		remaining := "0"
+		if n > 1 {
+			remaining = "4999"
+		}
+		w.Header().Set("X-RateLimit-Remaining", remaining)
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+		if _, err := w.Write([]byte(`{"login":"octocat"}`)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	if remaining, _ := client.RateLimit(); remaining != -1 {
+		t.Errorf("RateLimit() before any request = %d, want -1", remaining)
+	}
+
+	var user githubUser
+	if _, err := client.github.get(context.Background(), "/user", &user); err != nil {
+		t.Fatalf("get() error = %v", err)
+	}
+	remaining, gotReset := client.RateLimit()
+	if remaining != 0 || !gotReset.Equal(reset) {
+		t.Errorf("RateLimit() = %d, %v, want 0, %v", remaining, gotReset, reset)
+	}
+
+	// A deadline before the reset fails fast without sending the request.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	if _, err := client.github.get(ctx, "/user", &user); err == nil {
+		t.Error("expected an error when the deadline is before the rate limit reset")
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("expected no request while rate limited, server saw %d", n)
+	}
+
+	// Without a deadline the request waits for the reset and then goes through.
+	if _, err := client.github.get(context.Background(), "/user", &user); err != nil {
+		t.Fatalf("get() after waiting error = %v", err)
+	}
+	if time.Now().Before(reset) {
+		t.Error("request was sent before the rate limit reset")
+	}
+	if remaining, _ := client.RateLimit(); remaining != 4999 {
+		t.Errorf("RateLimit() after reset = %d, want 4999", remaining)
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"io"
 	"log/slog"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/codeGROOVE-dev/retry"
@@ -16,8 +18,11 @@ const (
 	retryAttempts = 10
 	// retryDelay is the initial retry delay.
 	retryDelay = 1 * time.Second
-	// retryMaxDelay is the maximum retry delay.
+	// retryMaxDelay is the maximum backoff delay when the server gives no hint.
 	retryMaxDelay = 2 * time.Minute
+	// retryMaxWait bounds server-requested waits from Retry-After or X-RateLimit-Reset;
+	// GitHub's primary rate limit window is one hour.
+	retryMaxWait = 1 * time.Hour
 	// retryMaxJitter adds randomness to prevent thundering herd.
 	retryMaxJitter = 1 * time.Second
 	// maxRequestSize limits request body size to prevent memory issues.
@@ -25,8 +30,13 @@ const (
 )
 
 // RetryTransport wraps an http.RoundTripper with retry logic using exponential backoff with jitter.
+// It retries 429s, 5xx responses, and 403s caused by GitHub rate limits, waiting as long
+// as Retry-After (or, failing that, X-RateLimit-Reset) asks when the server says.
 type RetryTransport struct {
-	Base http.RoundTripper
+	Base     http.RoundTripper
+	Attempts uint          // Maximum attempts, including the first; 0 means 10
+	Delay    time.Duration // Initial backoff delay, doubled each attempt; 0 means 1s
+	Logger   *slog.Logger  // Receives request and retry logs; nil discards them
 }
 
 // RoundTrip implements the http.RoundTripper interface with retry logic.
@@ -34,9 +44,13 @@ func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	if t.Base == nil {
 		t.Base = http.DefaultTransport
 	}
+	log := t.Logger
+	if log == nil {
+		log = discardLogger
+	}
 
 	// Log the outgoing request
-	slog.InfoContext(req.Context(), "HTTP request starting",
+	log.InfoContext(req.Context(), "HTTP request starting",
 		"method", req.Method,
 		"url", req.URL.String(),
 		"host", req.URL.Host)
@@ -49,10 +63,18 @@ func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 			return nil, err
 		}
 		if closeErr := req.Body.Close(); closeErr != nil {
-			slog.DebugContext(req.Context(), "failed to close request body", "error", closeErr, "url", req.URL.String())
+			log.DebugContext(req.Context(), "failed to close request body", "error", closeErr, "url", req.URL.String())
 		}
 	}
 
+	attempts, delay := t.Attempts, t.Delay
+	if attempts == 0 {
+		attempts = retryAttempts
+	}
+	if delay <= 0 {
+		delay = retryDelay
+	}
+
 	var resp *http.Response
 	var lastErr error
 
@@ -68,7 +90,7 @@ func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 			resp, err = t.Base.RoundTrip(req)
 			elapsed := time.Since(start)
 			if err != nil {
-				slog.ErrorContext(req.Context(), "HTTP request failed",
+				log.ErrorContext(req.Context(), "HTTP request failed",
 					"url", req.URL.String(),
 					"error", err,
 					"elapsed", elapsed)
@@ -76,33 +98,50 @@ func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 				return err
 			}
 
-			slog.InfoContext(req.Context(), "HTTP response received",
+			log.InfoContext(req.Context(), "HTTP response received",
 				"status", resp.StatusCode,
 				"url", req.URL.String(),
 				"elapsed", elapsed)
 
-			// Retry on 429 (rate limit) or 5xx server errors
-			if resp.StatusCode == http.StatusTooManyRequests || (resp.StatusCode >= 500 && resp.StatusCode < 600) {
+			// Retry on 429 (rate limit), 5xx server errors, or 403 secondary rate limits
+			if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusForbidden ||
+				(resp.StatusCode >= 500 && resp.StatusCode < 600) {
 				bodyBytes, _ := io.ReadAll(resp.Body)
 				if closeErr := resp.Body.Close(); closeErr != nil {
-					slog.DebugContext(req.Context(), "failed to close response body for retry", "error", closeErr)
+					log.DebugContext(req.Context(), "failed to close response body for retry", "error", closeErr)
 				}
 				resp.Body = io.NopCloser(bytes.NewReader(bodyBytes))
-				slog.InfoContext(req.Context(), "HTTP request will be retried",
+
+				reason := "retryable status code"
+				if resp.StatusCode == http.StatusForbidden {
+					// Plain 403s are permission errors; only rate limits are worth retrying.
+					switch {
+					case bytes.Contains(bytes.ToLower(bodyBytes), []byte("secondary rate limit")):
+						reason = "secondary rate limit"
+					case resp.Header.Get("X-RateLimit-Remaining") == "0":
+						reason = "rate limit exhausted"
+					default:
+						return nil
+					}
+				}
+
+				retryAfter := serverRetryDelay(resp, time.Now())
+				log.InfoContext(req.Context(), "HTTP request will be retried",
 					"status", resp.StatusCode,
 					"url", req.URL.String(),
-					"reason", "retryable status code")
-				lastErr = &retryableError{StatusCode: resp.StatusCode}
+					"reason", reason,
+					"retry_after", retryAfter)
+				lastErr = &retryableError{StatusCode: resp.StatusCode, RetryAfter: retryAfter}
 				return lastErr
 			}
 
 			return nil
 		},
 		retry.Context(req.Context()),
-		retry.Attempts(retryAttempts),
-		retry.Delay(retryDelay),
-		retry.MaxDelay(retryMaxDelay),
-		retry.DelayType(retry.BackOffDelay),
+		retry.Attempts(attempts),
+		retry.Delay(delay),
+		retry.MaxDelay(retryMaxWait),
+		retry.DelayType(retryDelayFor),
 		retry.MaxJitter(retryMaxJitter),
 		retry.RetryIf(func(err error) bool {
 			var retryErr *retryableError
@@ -112,13 +151,21 @@ func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 			// For any other error, ensure the response body is closed if it exists
 			if resp != nil && resp.Body != nil {
 				if closeErr := resp.Body.Close(); closeErr != nil {
-					slog.DebugContext(req.Context(), "failed to close response body on error", "error", closeErr)
+					log.DebugContext(req.Context(), "failed to close response body on error", "error", closeErr)
 				}
 			}
 			return false
 		}),
 	)
 	if err != nil {
+		if ctxErr := req.Context().Err(); ctxErr != nil {
+			if resp != nil && resp.Body != nil {
+				if closeErr := resp.Body.Close(); closeErr != nil {
+					log.DebugContext(req.Context(), "failed to close response body on cancel", "error", closeErr)
+				}
+			}
+			return nil, ctxErr
+		}
 		if lastErr != nil {
 			return resp, lastErr
 		}
@@ -128,9 +175,55 @@ func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	return resp, nil
 }
 
+// backoffWithJitter is the default delay between attempts.
+var backoffWithJitter = retry.CombineDelay(retry.BackOffDelay, retry.RandomDelay)
+
+// retryDelayFor honors a server-provided wait and otherwise backs off exponentially with jitter.
+func retryDelayFor(attempt uint, err error, config *retry.Config) time.Duration {
+	var retryErr *retryableError
+	if errors.As(err, &retryErr) && retryErr.RetryAfter > 0 {
+		return retryErr.RetryAfter
+	}
+	return min(backoffWithJitter(attempt, err, config), retryMaxDelay)
+}
+
+// serverRetryDelay returns how long GitHub asked us to wait before retrying, or 0 if it did not say.
+// Retry-After (seconds or HTTP-date) wins; rate-limited responses fall back to X-RateLimit-Reset.
+func serverRetryDelay(resp *http.Response, now time.Time) time.Duration {
+	if d := parseRetryAfter(resp.Header.Get("Retry-After"), now); d > 0 {
+		return d
+	}
+	if resp.StatusCode != http.StatusTooManyRequests && resp.Header.Get("X-RateLimit-Remaining") != "0" {
+		return 0
+	}
+	reset, err := strconv.ParseInt(strings.TrimSpace(resp.Header.Get("X-RateLimit-Reset")), 10, 64)
+	if err != nil {
+		return 0
+	}
+	// Allow a second of clock skew so we don't wake just before the window resets.
+	return max(time.Unix(reset, 0).Sub(now)+time.Second, 0)
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP-date,
+// returning 0 if absent, invalid, or already past.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0)
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(at.Sub(now), 0)
+	}
+	return 0
+}
+
 // retryableError indicates an error that should be retried.
 type retryableError struct {
 	StatusCode int
+	RetryAfter time.Duration // Server-requested wait before the next attempt; 0 if none
 }
 
 func (e *retryableError) Error() string {
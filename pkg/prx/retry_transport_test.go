@@ -0,0 +1,255 @@
+package prx
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestRetryTransport(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		body      string
+		header    map[string]string
+		wantCalls int
+		wantFinal int
+	}{
+		{
+			name:      "server error then success",
+			status:    http.StatusBadGateway,
+			wantCalls: 2,
+			wantFinal: http.StatusOK,
+		},
+		{
+			name:      "secondary rate limit then success",
+			status:    http.StatusForbidden,
+			body:      `{"message":"You have exceeded a Secondary Rate Limit."}`,
+			wantCalls: 2,
+			wantFinal: http.StatusOK,
+		},
+		{
+			name:      "secondary rate limit with retry-after",
+			status:    http.StatusForbidden,
+			body:      `{"message":"You have exceeded a secondary rate limit"}`,
+			header:    map[string]string{"Retry-After": "0"},
+			wantCalls: 2,
+			wantFinal: http.StatusOK,
+		},
+		{
+			name:      "exhausted rate limit then success",
+			status:    http.StatusForbidden,
+			body:      `{"message":"API rate limit exceeded"}`,
+			header:    map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "0"},
+			wantCalls: 2,
+			wantFinal: http.StatusOK,
+		},
+		{
+			name:      "permission denied is not retried",
+			status:    http.StatusForbidden,
+			body:      `{"message":"Resource not accessible by integration"}`,
+			wantCalls: 1,
+			wantFinal: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				calls++
+				if calls > 1 {
+					if _, err := w.Write([]byte(`{}`)); err != nil {
+						http.Error(w, err.Error(), http.StatusInternalServerError)
+					}
+					return
+				}
+				for k, v := range tt.header {
+					w.Header().Set(k, v)
+				}
+				http.Error(w, tt.body, tt.status)
+			}))
+			defer server.Close()
+
+			client := &http.Client{Transport: &RetryTransport{Base: http.DefaultTransport, Attempts: 3, Delay: time.Millisecond}}
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, http.NoBody)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("Do() error = %v", err)
+			}
+			defer func() {
+				if err := resp.Body.Close(); err != nil {
+					t.Errorf("close body: %v", err)
+				}
+			}()
+			if resp.StatusCode != tt.wantFinal {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantFinal)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestServerRetryDelay(t *testing.T) {
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name   string
+		status int
+		header map[string]string
+		want   time.Duration
+	}{
+		{name: "no hints", status: http.StatusTooManyRequests, want: 0},
+		{name: "retry-after seconds", status: http.StatusTooManyRequests, header: map[string]string{"Retry-After": "30"}, want: 30 * time.Second},
+		{name: "retry-after padded", status: http.StatusForbidden, header: map[string]string{"Retry-After": " 5 "}, want: 5 * time.Second},
+		{
+			name:   "retry-after http-date",
+			status: http.StatusTooManyRequests,
+			header: map[string]string{"Retry-After": now.Add(90 * time.Second).Format(http.TimeFormat)},
+			want:   90 * time.Second,
+		},
+		{
+			name:   "retry-after date in the past",
+			status: http.StatusTooManyRequests,
+			header: map[string]string{"Retry-After": now.Add(-time.Minute).Format(http.TimeFormat)},
+			want:   0,
+		},
+		{name: "retry-after garbage", status: http.StatusTooManyRequests, header: map[string]string{"Retry-After": "soon"}, want: 0},
+		{
+			name:   "falls back to rate limit reset on 429",
+			status: http.StatusTooManyRequests,
+			header: map[string]string{"X-RateLimit-Reset": strconv.FormatInt(now.Add(time.Minute).Unix(), 10)},
+			want:   61 * time.Second,
+		},
+		{
+			name:   "falls back to rate limit reset on exhausted 403",
+			status: http.StatusForbidden,
+			header: map[string]string{
+				"X-RateLimit-Remaining": "0",
+				"X-RateLimit-Reset":     strconv.FormatInt(now.Add(time.Minute).Unix(), 10),
+			},
+			want: 61 * time.Second,
+		},
+		{
+			name:   "ignores reset when limit remains",
+			status: http.StatusForbidden,
+			header: map[string]string{
+				"X-RateLimit-Remaining": "42",
+				"X-RateLimit-Reset":     strconv.FormatInt(now.Add(time.Minute).Unix(), 10),
+			},
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{StatusCode: tt.status, Header: http.Header{}}
+			for k, v := range tt.header {
+				resp.Header.Set(k, v)
+			}
+			if got := serverRetryDelay(resp, now); got != tt.want {
+				t.Errorf("serverRetryDelay() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryTransportCancel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		http.Error(w, `{"message":"secondary rate limit"}`, http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	client := &http.Client{Transport: &RetryTransport{Base: http.DefaultTransport}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, http.NoBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	resp, err := client.Do(req)
+	if resp != nil {
+		if err := resp.Body.Close(); err != nil {
+			t.Errorf("close body: %v", err)
+		}
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Do() error = %v, want deadline exceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Do() took %v after cancellation", elapsed)
+	}
+}
+
+func TestRetryOptions(t *testing.T) {
+	client := NewClient("token", WithRetryAttempts(4), WithRetryDelay(time.Millisecond))
+	gc, ok := client.github.(*githubClient)
+	if !ok {
+		t.Fatalf("unexpected github client type %T", client.github)
+	}
+	rt, ok := gc.client.Transport.(*RetryTransport)
+	if !ok {
+		t.Fatalf("unexpected transport type %T", gc.client.Transport)
+	}
+	if rt.Attempts != 4 || rt.Delay != time.Millisecond {
+		t.Errorf("RetryTransport = %+v, want 4 attempts and 1ms delay", rt)
+	}
+}
+
+func TestRetryOptionsKeepCallerTransport(t *testing.T) {
+	tests := []struct {
+		name         string
+		opts         []Option
+		wantAttempts uint
+		wantDelay    time.Duration
+	}{
+		{name: "no retry options", wantAttempts: 3, wantDelay: 5 * time.Millisecond},
+		{name: "attempts overridden", opts: []Option{WithRetryAttempts(6)}, wantAttempts: 6, wantDelay: 5 * time.Millisecond},
+		{name: "delay overridden", opts: []Option{WithRetryDelay(time.Second)}, wantAttempts: 3, wantDelay: time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rt := &RetryTransport{Base: http.DefaultTransport, Attempts: 3, Delay: 5 * time.Millisecond}
+			NewClient("token", append([]Option{WithHTTPClient(&http.Client{Transport: rt})}, tt.opts...)...)
+			if rt.Attempts != tt.wantAttempts || rt.Delay != tt.wantDelay {
+				t.Errorf("RetryTransport = %d attempts, %v delay; want %d, %v", rt.Attempts, rt.Delay, tt.wantAttempts, tt.wantDelay)
+			}
+		})
+	}
+}
+
+func TestRetryLoggerKeepsCallerTransport(t *testing.T) {
+	callerLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	optionLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	tests := []struct {
+		name   string
+		logger *slog.Logger
+		opts   []Option
+		want   *slog.Logger
+	}{
+		{name: "caller logger kept", logger: callerLogger, want: callerLogger},
+		{name: "nil logger filled", want: discardLogger},
+		{name: "WithLogger overrides", logger: callerLogger, opts: []Option{WithLogger(optionLogger)}, want: optionLogger},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rt := &RetryTransport{Base: http.DefaultTransport, Logger: tt.logger}
+			NewClient("token", append([]Option{WithHTTPClient(&http.Client{Transport: rt})}, tt.opts...)...)
+			if rt.Logger != tt.want {
+				t.Errorf("RetryTransport.Logger = %p, want %p", rt.Logger, tt.want)
+			}
+		})
+	}
+}
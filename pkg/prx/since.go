@@ -0,0 +1,29 @@
+package prx
+
+import (
+	"net/url"
+	"time"
+)
+
+// WithSince asks GitHub only for comments and review comments updated at or after t,
+// for incremental polling. Commits, reviews, the timeline, and checks have no such
+// filter on GitHub's side and are still fetched in full. CacheClient reads whole
+// pages from its cache and drops the older comments itself.
+func WithSince(t time.Time) Option {
+	return func(c *Client) {
+		c.since = t
+	}
+}
+
+// sincePath adds the since parameter from WithSince to path, if it was set.
+func (c *Client) sincePath(path string) string {
+	if c.since.IsZero() {
+		return path
+	}
+	return path + "?since=" + url.QueryEscape(c.since.UTC().Format(time.RFC3339))
+}
+
+// updatedSince reports whether a comment last updated at t passes the WithSince filter.
+func (c *Client) updatedSince(t time.Time) bool {
+	return !t.Before(c.since)
+}
@@ -0,0 +1,111 @@
+package prx
+
+import (
+	"context"
+	"errors"
+)
+
+// eventSinkKey carries the function StreamPullRequestEvents uses to receive
+// events as fetchers produce them.
+type eventSinkKey struct{}
+
+// withEventSink returns a context whose fetchers pass each event to sink as soon as it is built.
+func withEventSink(ctx context.Context, sink func(Event)) context.Context {
+	return context.WithValue(ctx, eventSinkKey{}, sink)
+}
+
+// streaming reports whether ctx carries an event sink. Fetchers then hand events to
+// the sink alone instead of also collecting them, so a stream never holds a whole timeline.
+func streaming(ctx context.Context) bool {
+	_, ok := ctx.Value(eventSinkKey{}).(func(Event))
+	return ok
+}
+
+// emit passes e to the context's event sink, if any.
+func emit(ctx context.Context, e Event) {
+	if sink, ok := ctx.Value(eventSinkKey{}).(func(Event)); ok {
+		sink(e)
+	}
+}
+
+// appendEvent passes e to the context's event sink if there is one, and otherwise
+// appends it to events.
+func appendEvent(ctx context.Context, events []Event, e Event) []Event {
+	if streaming(ctx) {
+		emit(ctx, e)
+		return events
+	}
+	return append(events, e)
+}
+
+// StreamPullRequestEvents sends a pull request's events as each page arrives, so
+// consumers can start on early events while later pages are still being fetched.
+//
+// Events arrive in fetch order, not chronologically; pr_opened comes first and
+// pr_merged or pr_closed last. Unlike PullRequest, events are not sorted, limited
+// by WithMaxEvents, grouped, or compacted, and write access is not upgraded from
+// what other events reveal. With WithGraphQL the events come from the GraphQL
+// query, limited to the kinds chosen with WithGraphQLEventKinds.
+//
+// The event channel is closed when fetching finishes. The error channel then
+// yields at most one error, joining the failures of any sources that could not be
+// fetched, before it is closed as well. Stop early by cancelling ctx.
+func (c *Client) StreamPullRequestEvents(ctx context.Context, owner, repo string, prNumber int) (<-chan Event, <-chan error) {
+	events := make(chan Event, maxPerPage)
+	errc := make(chan error, 1)
+
+	go func() {
+		defer close(errc)
+		defer close(events)
+
+		id := traceID(ctx)
+		send := func(e Event) {
+			if !keepEvent(e) {
+				return
+			}
+			e.TraceID = id
+			select {
+			case events <- e:
+			case <-ctx.Done():
+			}
+		}
+
+		// GraphQL sources filter the events they return, which streaming never builds,
+		// so kinds left out with WithGraphQLEventKinds are dropped on the way out instead.
+		sinkCtx := withEventSink(ctx, func(e Event) {
+			if c.graphQLKinds == nil || c.graphQLKinds[e.Kind] {
+				send(e)
+			}
+		})
+
+		c.logger.InfoContext(ctx, "streaming pull request events", "owner", owner, "repo", repo, "pr", prNumber)
+		var pr *githubPullRequest
+		var sources []source
+		var err error
+		if c.graphQL {
+			pr, sources, err = c.graphQLPullRequest(sinkCtx, owner, repo, prNumber)
+		} else if pr, err = c.pullRequest(ctx, owner, repo, prNumber); err == nil {
+			sources = c.eventSources(sinkCtx, owner, repo, pr)
+		}
+		if err != nil {
+			errc <- err
+			return
+		}
+		send(c.openedEvent(ctx, owner, repo, pr))
+
+		_, errs := c.fetchSources(sinkCtx, sources)
+
+		if closing := c.closingEvent(ctx, owner, repo, pr); closing != nil {
+			send(*closing)
+		}
+		if err := ctx.Err(); err != nil {
+			errc <- err
+			return
+		}
+		if err := errors.Join(errs...); err != nil {
+			errc <- err
+		}
+	}()
+
+	return events, errc
+}
@@ -0,0 +1,211 @@
+package prx
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// failingGithubClient fails requests for one path and delegates the rest.
+type failingGithubClient struct {
+	*mockGithubClient
+	failPath string
+}
+
+func (f *failingGithubClient) get(ctx context.Context, path string, v any) (*githubResponse, error) {
+	if path == f.failPath {
+		return nil, &GitHubAPIError{StatusCode: http.StatusBadGateway, Status: "502 Bad Gateway"}
+	}
+	return f.mockGithubClient.get(ctx, path, v)
+}
+
+func TestStreamPullRequestEvents(t *testing.T) {
+	at := func(minutes int) githubTime {
+		return githubTime{Time: time.Date(2024, 1, 1, 10, minutes, 0, 0, time.UTC)}
+	}
+	mock := &mockGithubClient{
+		responses: map[string]any{
+			"/repos/owner/repo/pulls/1": githubPullRequest{
+				Number:    1,
+				State:     "closed",
+				Merged:    true,
+				CreatedAt: at(0),
+				MergedAt:  at(30),
+				User:      &githubUser{Login: "author"},
+				MergedBy:  &githubUser{Login: "maintainer"},
+				Head:      githubRef{SHA: "abc123"},
+			},
+			"/repos/owner/repo/issues/1/comments?page=1&per_page=100": []*githubComment{
+				{User: &githubUser{Login: "reviewer"}, Body: "looks good", CreatedAt: at(5)},
+			},
+			"/repos/owner/repo/pulls/1/reviews?page=1&per_page=100": []*githubReview{
+				{User: &githubUser{Login: "reviewer"}, State: "APPROVED", SubmittedAt: at(10)},
+			},
+			"/repos/owner/repo/statuses/abc123?page=1&per_page=100": []*githubStatus{
+				{Context: "ci/lint", State: "success", CreatedAt: at(2), Creator: &githubUser{Login: "ci"}},
+				{Context: "ci/test", State: "failure", CreatedAt: at(3), Creator: &githubUser{Login: "ci"}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		failing string
+		wantErr bool
+		want    []string
+	}{
+		{
+			name: "all sources",
+			want: []string{"comment", "pr_merged", "pr_opened", "review", "status_check"},
+		},
+		{
+			name:    "failed source",
+			failing: "/repos/owner/repo/pulls/1/reviews?page=1&per_page=100",
+			wantErr: true,
+			want:    []string{"comment", "pr_merged", "pr_opened", "status_check"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &Client{
+				github:          &failingGithubClient{mockGithubClient: mock, failPath: tt.failing},
+				logger:          slog.Default(),
+				permissionCache: &permissionCache{memory: make(map[string]permissionEntry)},
+			}
+			ctx := ContextWithTraceID(context.Background(), "delivery-1")
+			events, errc := client.StreamPullRequestEvents(ctx, "owner", "repo", 1)
+
+			var kinds []string
+			var first, last string
+			for e := range events {
+				if first == "" {
+					first = e.Kind
+				}
+				last = e.Kind
+				if e.TraceID != "delivery-1" {
+					t.Errorf("%s event TraceID = %q", e.Kind, e.TraceID)
+				}
+				kinds = append(kinds, e.Kind)
+			}
+			err := <-errc
+
+			if first != "pr_opened" || last != "pr_merged" {
+				t.Errorf("stream began with %q and ended with %q", first, last)
+			}
+			slices.Sort(kinds)
+			if !slices.Equal(kinds, tt.want) {
+				t.Errorf("streamed kinds = %v, want %v", kinds, tt.want)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("stream error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStreamPullRequestEventsNotFound(t *testing.T) {
+	client := &Client{
+		github: &failingGithubClient{
+			mockGithubClient: &mockGithubClient{responses: map[string]any{}},
+			failPath:         "/repos/owner/repo/pulls/1",
+		},
+		logger: slog.Default(),
+	}
+	events, errc := client.StreamPullRequestEvents(context.Background(), "owner", "repo", 1)
+	for e := range events {
+		t.Errorf("unexpected event %+v", e)
+	}
+	var apiErr *GitHubAPIError
+	if err := <-errc; !errors.As(err, &apiErr) {
+		t.Errorf("stream error = %v, want *GitHubAPIError", err)
+	}
+}
+
+func TestStreamPullRequestEventsGraphQL(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+	}{
+		{name: "all kinds", opts: []Option{WithGraphQL()}},
+		{name: "selected kinds", opts: []Option{WithGraphQLEventKinds(EventKindReview)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var requests []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				requests = append(requests, r.Method+" "+r.URL.Path)
+				mu.Unlock()
+				if r.URL.Path != "/graphql" {
+					http.NotFound(w, r)
+					return
+				}
+				if _, err := w.Write([]byte(strings.Replace(graphQLPullRequestResponse, "commitsHasNextPage", "false", 1))); err != nil {
+					http.Error(w, err.Error(), http.StatusInternalServerError)
+				}
+			}))
+			defer server.Close()
+
+			client := newTestClient(t, server, tt.opts...)
+			data, err := client.PullRequest(context.Background(), "owner", "repo", 1)
+			if err != nil {
+				t.Fatalf("PullRequest() error = %v", err)
+			}
+			var want []string
+			for _, e := range data.Events {
+				want = append(want, e.Kind)
+			}
+			slices.Sort(want)
+
+			mu.Lock()
+			requests = nil
+			mu.Unlock()
+			events, errc := client.StreamPullRequestEvents(context.Background(), "owner", "repo", 1)
+			var kinds []string
+			for e := range events {
+				kinds = append(kinds, e.Kind)
+			}
+			if err := <-errc; err != nil {
+				t.Fatalf("stream error = %v", err)
+			}
+			slices.Sort(kinds)
+			if !slices.Equal(kinds, want) {
+				t.Errorf("streamed kinds = %v, want the PullRequest kinds %v", kinds, want)
+			}
+			if want := []string{"POST /graphql"}; !slices.Equal(requests, want) {
+				t.Errorf("requests = %q, want %q", requests, want)
+			}
+		})
+	}
+}
+
+func TestAppendEventStreaming(t *testing.T) {
+	var streamed []Event
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		wantEvents int
+		wantSink   int
+	}{
+		{name: "collected", ctx: context.Background(), wantEvents: 1},
+		{name: "streamed only", ctx: withEventSink(context.Background(), func(e Event) { streamed = append(streamed, e) }), wantSink: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streamed = nil
+			events := appendEvent(tt.ctx, nil, Event{Kind: EventKindComment})
+			if len(events) != tt.wantEvents || len(streamed) != tt.wantSink {
+				t.Errorf("appendEvent() kept %d and streamed %d events, want %d and %d", len(events), len(streamed), tt.wantEvents, tt.wantSink)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package prx
+
+// Timeline is the chronological list of events for a pull request.
+type Timeline []Event
+
+// UIGroup is a run of events that GitHub's web UI renders as a single timeline item.
+type UIGroup struct {
+	Kind   string  `json:"kind"`   // Kind of the leading event
+	Events []Event `json:"events"` // The leading event followed by any events folded into it
+}
+
+// UIGroups groups the timeline the way GitHub's pull request page presents it:
+//
+//   - Consecutive commits collapse into a single group.
+//   - A review is grouped with the inline review comments it delivered (matched by
+//     ReviewID), even when a comment's timestamp lands slightly before or after the review.
+//   - Every other event, including review comments whose review is not in the
+//     timeline, is a group of its own.
+//
+// Groups keep the order of their leading events.
+func (t Timeline) UIGroups() []UIGroup {
+	reviews := make(map[int64]bool)
+	for i := range t {
+		if t[i].Kind == EventKindReview && t[i].ReviewID != 0 {
+			reviews[t[i].ReviewID] = true
+		}
+	}
+
+	var groups []UIGroup
+	reviewGroup := make(map[int64]int) // ReviewID -> index into groups
+	early := make(map[int64][]Event)   // comments seen before their review
+	for _, e := range t {
+		switch {
+		case e.Kind == EventKindCommit && len(groups) > 0 && groups[len(groups)-1].Kind == EventKindCommit:
+			last := &groups[len(groups)-1]
+			last.Events = append(last.Events, e)
+		case e.Kind == EventKindReviewComment && reviews[e.ReviewID]:
+			if i, ok := reviewGroup[e.ReviewID]; ok {
+				groups[i].Events = append(groups[i].Events, e)
+			} else {
+				early[e.ReviewID] = append(early[e.ReviewID], e)
+			}
+		case e.Kind == EventKindReview && reviews[e.ReviewID]:
+			reviewGroup[e.ReviewID] = len(groups)
+			groups = append(groups, UIGroup{Kind: e.Kind, Events: append([]Event{e}, early[e.ReviewID]...)})
+			delete(early, e.ReviewID)
+		default:
+			groups = append(groups, UIGroup{Kind: e.Kind, Events: []Event{e}})
+		}
+	}
+	return groups
+}
+
+// BotRatio returns the fraction of events performed by bots, from 0 to 1.
+// Status checks and check runs are excluded: they are always posted by apps and
+// would make every PR with CI look automated. An empty timeline has a ratio of 0.
+func (t Timeline) BotRatio() float64 {
+	var total, bots int
+	for i := range t {
+		if t[i].Kind == EventKindStatusCheck || t[i].Kind == EventKindCheckRun {
+			continue
+		}
+		total++
+		if t[i].Bot {
+			bots++
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return float64(bots) / float64(total)
+}
+
+// IsBotDominated reports whether the share of bot activity, as measured by
+// BotRatio, is at least threshold.
+func (t Timeline) IsBotDominated(threshold float64) bool {
+	return len(t) > 0 && t.BotRatio() >= threshold
+}
+
+// CommitSHAs returns the SHA of every commit in the timeline, in timeline order.
+// Each SHA appears once, and commits without a SHA are skipped.
+func (t Timeline) CommitSHAs() []string {
+	var shas []string
+	seen := make(map[string]bool)
+	for i := range t {
+		if t[i].Kind != EventKindCommit || t[i].SHA == "" || seen[t[i].SHA] {
+			continue
+		}
+		seen[t[i].SHA] = true
+		shas = append(shas, t[i].SHA)
+	}
+	return shas
+}
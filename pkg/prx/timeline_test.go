@@ -0,0 +1,128 @@
+package prx
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestUIGroups(t *testing.T) {
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	at := func(minutes int) time.Time { return base.Add(time.Duration(minutes) * time.Minute) }
+
+	timeline := Timeline{
+		{Kind: "pr_opened", Timestamp: at(0), Actor: "author"},
+		{Kind: "commit", Timestamp: at(1), Actor: "author", Body: "one"},
+		{Kind: "commit", Timestamp: at(2), Actor: "author", Body: "two"},
+		{Kind: "commit", Timestamp: at(3), Actor: "author", Body: "three"},
+		// Inline comment stamped a moment before the review that delivered it.
+		{Kind: "review_comment", Timestamp: at(9), Actor: "reviewer", Body: "nit", ReviewID: 7},
+		{Kind: "review", Timestamp: at(10), Actor: "reviewer", Outcome: "changes_requested", ReviewID: 7},
+		{Kind: "review_comment", Timestamp: at(10), Actor: "reviewer", Body: "typo", ReviewID: 7},
+		{Kind: "comment", Timestamp: at(11), Actor: "author", Body: "fixed"},
+		{Kind: "commit", Timestamp: at(12), Actor: "author", Body: "four"},
+		{Kind: "review_comment", Timestamp: at(13), Actor: "other", Body: "orphan", ReviewID: 99},
+	}
+
+	groups := timeline.UIGroups()
+
+	wantKinds := []string{"pr_opened", "commit", "review", "comment", "commit", "review_comment"}
+	if len(groups) != len(wantKinds) {
+		t.Fatalf("expected %d groups, got %d: %+v", len(wantKinds), len(groups), groups)
+	}
+	for i, kind := range wantKinds {
+		if groups[i].Kind != kind {
+			t.Errorf("group %d kind = %q, want %q", i, groups[i].Kind, kind)
+		}
+	}
+
+	if n := len(groups[1].Events); n != 3 {
+		t.Errorf("expected consecutive commits collapsed into one group of 3, got %d", n)
+	}
+	if n := len(groups[4].Events); n != 1 {
+		t.Errorf("commit after a comment should start a new group, got %d events", n)
+	}
+
+	review := groups[2].Events
+	if len(review) != 3 {
+		t.Fatalf("expected review grouped with its 2 comments, got %d events", len(review))
+	}
+	if review[0].Kind != "review" || review[1].Body != "nit" || review[2].Body != "typo" {
+		t.Errorf("unexpected review group order: %+v", review)
+	}
+}
+
+func TestBotRatio(t *testing.T) {
+	tests := []struct {
+		name      string
+		timeline  Timeline
+		wantRatio float64
+		dominated bool // at a 0.8 threshold
+	}{
+		{
+			name:     "empty",
+			timeline: Timeline{},
+		},
+		{
+			name: "all bot dependency update",
+			timeline: Timeline{
+				{Kind: "pr_opened", Actor: "dependabot[bot]", Bot: true},
+				{Kind: "commit", Actor: "dependabot[bot]", Bot: true},
+				{Kind: "check_run", Actor: "github-actions", Bot: true},
+				{Kind: "pr_merged", Actor: "dependabot[bot]", Bot: true},
+			},
+			wantRatio: 1,
+			dominated: true,
+		},
+		{
+			name: "mixed",
+			timeline: Timeline{
+				{Kind: "pr_opened", Actor: "dev"},
+				{Kind: "commit", Actor: "dev"},
+				{Kind: "comment", Actor: "codecov[bot]", Bot: true},
+				{Kind: "review", Actor: "reviewer"},
+				{Kind: "status_check", Actor: "ci", Bot: true},
+				{Kind: "check_run", Actor: "github-actions", Bot: true},
+			},
+			wantRatio: 0.25,
+		},
+		{
+			name: "only checks",
+			timeline: Timeline{
+				{Kind: "check_run", Actor: "github-actions", Bot: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.timeline.BotRatio(); got != tt.wantRatio {
+				t.Errorf("BotRatio() = %v, want %v", got, tt.wantRatio)
+			}
+			if got := tt.timeline.IsBotDominated(0.8); got != tt.dominated {
+				t.Errorf("IsBotDominated(0.8) = %v, want %v", got, tt.dominated)
+			}
+		})
+	}
+}
+
+func TestCommitSHAs(t *testing.T) {
+	timeline := Timeline{
+		{Kind: "pr_opened", Actor: "dev"},
+		{Kind: "commit", SHA: "aaa"},
+		{Kind: "comment", Actor: "reviewer"},
+		{Kind: "commit", SHA: "bbb"},
+		{Kind: "commit"},
+		{Kind: "commit", SHA: "aaa"}, // Same commit reported twice
+		{Kind: "commit", SHA: "ccc"},
+	}
+
+	got := timeline.CommitSHAs()
+	want := []string{"aaa", "bbb", "ccc"}
+	if !slices.Equal(got, want) {
+		t.Errorf("CommitSHAs() = %v, want %v", got, want)
+	}
+	if shas := (Timeline{{Kind: "comment"}}).CommitSHAs(); len(shas) != 0 {
+		t.Errorf("CommitSHAs() without commits = %v, want empty", shas)
+	}
+}
@@ -0,0 +1,29 @@
+package prx
+
+import "context"
+
+type traceIDKey struct{}
+
+// ContextWithTraceID returns a copy of ctx carrying id, such as a webhook delivery ID.
+// Fetches made with the returned context stamp id into every Event's TraceID and
+// include it in their request logs, tying stored events back to what triggered them.
+func ContextWithTraceID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, traceIDKey{}, id)
+}
+
+// traceID returns the trace ID attached to ctx, or "" if there is none.
+func traceID(ctx context.Context) string {
+	id, _ := ctx.Value(traceIDKey{}).(string)
+	return id
+}
+
+// stampTraceID sets TraceID on each event when ctx carries a trace ID.
+func stampTraceID(ctx context.Context, events []Event) {
+	id := traceID(ctx)
+	if id == "" {
+		return
+	}
+	for i := range events {
+		events[i].TraceID = id
+	}
+}
@@ -0,0 +1,53 @@
+package prx
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestTraceIDPropagatesToEvents(t *testing.T) {
+	mock := &mockGithubClient{
+		responses: map[string]any{
+			"/repos/owner/repo/pulls/1": githubPullRequest{
+				Number:    1,
+				State:     "open",
+				CreatedAt: githubTime{Time: time.Now().Add(-time.Hour)},
+				User:      &githubUser{Login: "author"},
+			},
+			"/repos/owner/repo/issues/1/comments?page=1&per_page=100": []*githubComment{
+				{User: &githubUser{Login: "reviewer"}, Body: "looks good", CreatedAt: githubTime{Time: time.Now()}},
+			},
+		},
+	}
+	client := &Client{
+		github:          mock,
+		logger:          slog.Default(),
+		permissionCache: &permissionCache{memory: make(map[string]permissionEntry)},
+	}
+
+	ctx := ContextWithTraceID(context.Background(), "delivery-123")
+	data, err := client.PullRequest(ctx, "owner", "repo", 1)
+	if err != nil {
+		t.Fatalf("PullRequest() error = %v", err)
+	}
+	if len(data.Events) < 2 {
+		t.Fatalf("expected at least 2 events, got %d", len(data.Events))
+	}
+	for _, e := range data.Events {
+		if e.TraceID != "delivery-123" {
+			t.Errorf("%s event TraceID = %q, want %q", e.Kind, e.TraceID, "delivery-123")
+		}
+	}
+
+	data, err = client.PullRequest(context.Background(), "owner", "repo", 1)
+	if err != nil {
+		t.Fatalf("PullRequest() error = %v", err)
+	}
+	for _, e := range data.Events {
+		if e.TraceID != "" {
+			t.Errorf("%s event TraceID = %q without a trace context", e.Kind, e.TraceID)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package prx
+
+import (
+	"context"
+	"errors"
+	"net/url"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// WithTracer records each GitHub API request as a client span from tracer, with the
+// method, path, and response status as attributes. The span covers the whole request,
+// including rate limit waits and retries, so its duration is the request's latency.
+// Failed requests are marked as errors. Without a tracer no spans are created.
+func WithTracer(tracer trace.Tracer) Option {
+	return func(c *Client) {
+		c.tracer = tracer
+	}
+}
+
+// startSpan begins the span for a request to apiURL, or returns a no-op span when
+// tracing is off.
+func (c *githubClient) startSpan(ctx context.Context, method, apiURL string) (context.Context, trace.Span) {
+	if c.tracer == nil {
+		return ctx, noop.Span{}
+	}
+	attrs := []attribute.KeyValue{attribute.String("http.request.method", method)}
+	// The query string is left out, as paging parameters would only add noise.
+	if u, err := url.Parse(apiURL); err == nil {
+		attrs = append(attrs, attribute.String("server.address", u.Host), attribute.String("url.path", u.Path))
+	}
+	return c.tracer.Start(ctx, method, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+}
+
+// recordStatus adds the HTTP response status to span.
+func recordStatus(span trace.Span, status int) {
+	span.SetAttributes(attribute.Int("http.response.status_code", status))
+}
+
+// endSpan marks span as failed if err is set and ends it.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		msg := redactTokens(err.Error())
+		span.RecordError(errors.New(msg))
+		span.SetStatus(codes.Error, msg)
+	}
+	span.End()
+}
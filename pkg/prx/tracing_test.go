@@ -0,0 +1,81 @@
+package prx
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestWithTracer(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int64
+		wantCode   codes.Code
+	}{
+		{name: "success", path: "/repos/owner/repo", wantStatus: http.StatusOK, wantCode: codes.Unset},
+		{name: "not found", path: "/repos/owner/missing", wantStatus: http.StatusNotFound, wantCode: codes.Error},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/repos/owner/repo" {
+					http.NotFound(w, r)
+					return
+				}
+				if _, err := w.Write([]byte(`{"name": "repo"}`)); err != nil {
+					http.Error(w, err.Error(), http.StatusInternalServerError)
+				}
+			}))
+			defer server.Close()
+
+			recorder := tracetest.NewSpanRecorder()
+			provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+			client := newTestClient(t, server, WithTracer(provider.Tracer("prx-test")))
+
+			var v map[string]any
+			_, err := client.github.get(context.Background(), tt.path+"?per_page=100", &v)
+			if (err != nil) != (tt.wantCode == codes.Error) {
+				t.Fatalf("get() error = %v", err)
+			}
+
+			spans := recorder.Ended()
+			if len(spans) != 1 {
+				t.Fatalf("recorded %d spans, want 1", len(spans))
+			}
+			span := spans[0]
+			if span.Name() != http.MethodGet || span.SpanKind() != trace.SpanKindClient {
+				t.Errorf("span = %q (%v), want GET client span", span.Name(), span.SpanKind())
+			}
+			attrs := make(map[attribute.Key]attribute.Value)
+			for _, kv := range span.Attributes() {
+				attrs[kv.Key] = kv.Value
+			}
+			if got := attrs["http.request.method"].AsString(); got != http.MethodGet {
+				t.Errorf("http.request.method = %q, want GET", got)
+			}
+			if got := attrs["url.path"].AsString(); got != tt.path {
+				t.Errorf("url.path = %q, want %q", got, tt.path)
+			}
+			if got := attrs["http.response.status_code"].AsInt64(); got != tt.wantStatus {
+				t.Errorf("http.response.status_code = %d, want %d", got, tt.wantStatus)
+			}
+			if span.Status().Code != tt.wantCode {
+				t.Errorf("status = %v, want %v", span.Status().Code, tt.wantCode)
+			}
+			if tt.wantCode == codes.Error && len(span.Events()) == 0 {
+				t.Error("error was not recorded on the span")
+			}
+			if !span.EndTime().After(span.StartTime()) {
+				t.Errorf("span ended at %v, not after its start %v", span.EndTime(), span.StartTime())
+			}
+		})
+	}
+}
@@ -125,6 +125,25 @@ func TestUpgradeWriteAccess(t *testing.T) {
 				"user2": WriteAccessDefinitely, // Should be upgraded to 2
 			},
 		},
+		{
+			name: "upgrade unknown write access",
+			events: []Event{
+				{
+					Kind:        "comment",
+					Timestamp:   now.Add(-2 * time.Hour),
+					Actor:       "user1",
+					WriteAccess: WriteAccessUnknown,
+				},
+				{
+					Kind:      "labeled",
+					Timestamp: now.Add(-1 * time.Hour),
+					Actor:     "user1",
+				},
+			},
+			expected: map[string]int{
+				"user1": WriteAccessDefinitely,
+			},
+		},
 		{
 			name: "handle events with nil write access",
 			events: []Event{
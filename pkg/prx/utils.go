@@ -1,8 +1,10 @@
 package prx
 
 import (
-	"sort"
+	"regexp"
+	"slices"
 	"strings"
+	"unicode/utf8"
 )
 
 var questionPatterns = []string{
@@ -34,8 +36,40 @@ var questionPatterns = []string{
 	"need help",
 }
 
+// inlineCode matches a `code span` on a single line.
+var inlineCode = regexp.MustCompile("`[^`\n]*`")
+
+// authorProse removes what the author did not write as prose from markdown: fenced code
+// blocks and inline code spans, whose ternaries and regexes would look like questions,
+// and ">" quoted lines, which often repeat the question being answered. A fence left
+// open, as when a body was truncated, runs to the end of the text.
+func authorProse(text string) string {
+	if !strings.ContainsAny(text, "`~>") {
+		return text
+	}
+	var prose []string
+	var fence string
+	for _, line := range strings.SplitAfter(text, "\n") {
+		trimmed := strings.TrimLeft(line, " \t")
+		switch {
+		case fence != "":
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+		case strings.HasPrefix(trimmed, "```"), strings.HasPrefix(trimmed, "~~~"):
+			fence = trimmed[:3]
+		case strings.HasPrefix(trimmed, ">"):
+		default:
+			prose = append(prose, line)
+		}
+	}
+	return inlineCode.ReplaceAllString(strings.Join(prose, ""), "")
+}
+
 func containsQuestion(text string) bool {
-	if strings.Contains(text, "?") {
+	text = authorProse(text)
+	// ASCII and full-width question marks (CJK), and Spanish's opening ¿.
+	if strings.ContainsAny(text, "?？¿") {
 		return true
 	}
 	lowerText := strings.ToLower(text)
@@ -47,10 +81,93 @@ func containsQuestion(text string) bool {
 	return false
 }
 
-func sortEventsByTimestamp(events []Event) {
-	sort.Slice(events, func(i, j int) bool {
-		return events[i].Timestamp.Before(events[j].Timestamp)
+// SortEvents orders events chronologically. Events sharing a timestamp are
+// ordered by Kind and then Actor, so the same input always sorts the same way
+// regardless of which fetcher returned first.
+func SortEvents(events []Event) {
+	slices.SortStableFunc(events, func(a, b Event) int {
+		if c := a.Timestamp.Compare(b.Timestamp); c != 0 {
+			return c
+		}
+		if c := strings.Compare(a.Kind, b.Kind); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Actor, b.Actor)
+	})
+}
+
+// CurrentLabels replays labeled and unlabeled events in timestamp order and returns
+// the labels still applied at the end, ordered by when each was last added.
+// Events sharing a timestamp are replayed in the order given.
+func CurrentLabels(events []Event) []string {
+	var changes []Event
+	for _, e := range events {
+		if (e.Kind == EventKindLabeled || e.Kind == EventKindUnlabeled) && e.Target != "" {
+			changes = append(changes, e)
+		}
+	}
+	slices.SortStableFunc(changes, func(a, b Event) int {
+		return a.Timestamp.Compare(b.Timestamp)
 	})
+
+	var labels []string
+	for _, e := range changes {
+		labels = slices.DeleteFunc(labels, func(l string) bool { return l == e.Target })
+		if e.Kind == EventKindLabeled {
+			labels = append(labels, e.Target)
+		}
+	}
+	return labels
+}
+
+// coAuthors returns the value of each Co-authored-by trailer in a commit message,
+// such as "Jane Doe <jane@example.com>", in order and without duplicates.
+func coAuthors(message string) []string {
+	var authors []string
+	for _, line := range strings.Split(message, "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if !ok || !strings.EqualFold(strings.TrimSpace(key), "Co-authored-by") {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		if value != "" && !slices.Contains(authors, value) {
+			authors = append(authors, value)
+		}
+	}
+	return authors
+}
+
+// groupByReview moves each review comment to directly after the review that
+// delivered it, matched by ReviewID. GitHub timestamps a review and its inline
+// comments slightly apart, so a chronological sort can interleave them with other
+// events. Comments whose review is not among events keep their position.
+func groupByReview(events []Event) []Event {
+	reviews := make(map[int64]bool)
+	for i := range events {
+		if events[i].Kind == EventKindReview && events[i].ReviewID != 0 {
+			reviews[events[i].ReviewID] = true
+		}
+	}
+	comments := make(map[int64][]Event)
+	for i := range events {
+		if events[i].Kind == EventKindReviewComment && reviews[events[i].ReviewID] {
+			comments[events[i].ReviewID] = append(comments[events[i].ReviewID], events[i])
+		}
+	}
+
+	grouped := make([]Event, 0, len(events))
+	for i := range events {
+		e := events[i]
+		if e.Kind == EventKindReviewComment && reviews[e.ReviewID] {
+			continue
+		}
+		grouped = append(grouped, e)
+		if e.Kind == EventKindReview {
+			grouped = append(grouped, comments[e.ReviewID]...)
+			delete(comments, e.ReviewID)
+		}
+	}
+	return grouped
 }
 
 func isHexString(s string) bool {
@@ -63,11 +180,24 @@ func isHexString(s string) bool {
 	return true
 }
 
+// ellipsis marks text that truncate shortened.
+const ellipsis = "…"
+
+// truncate shortens s to at most maxLen bytes, cutting on a rune boundary so the
+// result stays valid UTF-8, and ends it with an ellipsis when anything was removed.
 func truncate(s string, maxLen int) string {
 	if len(s) <= maxLen {
 		return s
 	}
-	return s[:maxLen]
+	marker := ellipsis
+	if maxLen <= len(ellipsis) {
+		marker = ""
+	}
+	cut := maxLen - len(marker)
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + marker
 }
 
 func calculateTestSummary(events []Event) *TestSummary {
@@ -153,25 +283,22 @@ func calculateApprovalSummary(events []Event) *ApprovalSummary {
 
 func filterEvents(events []Event) []Event {
 	filtered := make([]Event, 0, len(events))
-
 	for _, event := range events {
-		// Include all non-status_check events
-		if event.Kind != "status_check" {
-			filtered = append(filtered, event)
-			continue
-		}
-
-		// For status_check events, only include if outcome is failure
-		if event.Outcome == "failure" {
+		if keepEvent(event) {
 			filtered = append(filtered, event)
 		}
 	}
-
 	return filtered
 }
 
-// upgradeWriteAccess scans through events and upgrades write_access from 1 (likely) to 2 (definitely)
-// for actors who have performed actions that require write access.
+// keepEvent reports whether an event belongs in the timeline: every event
+// except status checks, which are only kept when they failed.
+func keepEvent(event Event) bool {
+	return event.Kind != "status_check" || event.Outcome == "failure"
+}
+
+// upgradeWriteAccess scans through events and upgrades write_access from likely or unknown to
+// definitely for actors who have performed actions that require write access.
 func upgradeWriteAccess(events []Event) {
 	// Track actors who have definitely demonstrated write access
 	confirmedWriteAccess := make(map[string]bool)
@@ -187,9 +314,9 @@ func upgradeWriteAccess(events []Event) {
 		}
 	}
 
-	// Second pass: upgrade write_access from 1 to 2 for confirmed actors
+	// Second pass: upgrade write_access to definitely for confirmed actors
 	for i := range events {
-		if events[i].WriteAccess == WriteAccessLikely {
+		if events[i].WriteAccess == WriteAccessLikely || events[i].WriteAccess == WriteAccessUnknown {
 			if confirmedWriteAccess[events[i].Actor] {
 				events[i].WriteAccess = WriteAccessDefinitely
 			}
@@ -0,0 +1,162 @@
+package prx
+
+import (
+	"fmt"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestSortEvents(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	t1 := t0.Add(time.Minute)
+	events := []Event{
+		{Kind: "review", Actor: "bob", Timestamp: t1},
+		{Kind: "comment", Actor: "carol", Timestamp: t1},
+		{Kind: "comment", Actor: "alice", Timestamp: t1},
+		{Kind: "pr_opened", Actor: "alice", Timestamp: t0},
+		{Kind: "check_run", Actor: "github", Timestamp: t1.Add(time.Second)},
+	}
+	want := "[pr_opened/alice comment/alice comment/carol review/bob check_run/github]"
+
+	// Sorting any permutation must yield the same order.
+	for i := range events {
+		shuffled := append(append([]Event{}, events[i:]...), events[:i]...)
+		SortEvents(shuffled)
+		var got []string
+		for _, e := range shuffled {
+			got = append(got, e.Kind+"/"+e.Actor)
+		}
+		if fmt.Sprint(got) != want {
+			t.Errorf("rotation %d: SortEvents() = %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name   string
+		s      string
+		maxLen int
+		want   string
+	}{
+		{name: "short", s: "hello", maxLen: 10, want: "hello"},
+		{name: "exact", s: "hello", maxLen: 5, want: "hello"},
+		{name: "ascii", s: "hello world", maxLen: 8, want: "hello…"},
+		{name: "emoji at boundary", s: "ab🎉🎉🎉", maxLen: 8, want: "ab…"},
+		{name: "emoji fits", s: "ab🎉🎉🎉", maxLen: 9, want: "ab🎉…"},
+		{name: "cjk", s: "日本語のテキスト", maxLen: 10, want: "日本…"},
+		{name: "cjk mid rune", s: "日本語のテキスト", maxLen: 11, want: "日本…"},
+		{name: "tiny limit", s: "日本語", maxLen: 2, want: ""},
+		{name: "tiny ascii limit", s: "hello", maxLen: 2, want: "he"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := truncate(tt.s, tt.maxLen)
+			if got != tt.want {
+				t.Errorf("truncate(%q, %d) = %q, want %q", tt.s, tt.maxLen, got, tt.want)
+			}
+			if !utf8.ValidString(got) {
+				t.Errorf("truncate(%q, %d) = %q is not valid UTF-8", tt.s, tt.maxLen, got)
+			}
+			if len(got) > tt.maxLen {
+				t.Errorf("truncate(%q, %d) is %d bytes, over the limit", tt.s, tt.maxLen, len(got))
+			}
+		})
+	}
+}
+
+func TestCurrentLabels(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	at := func(minutes int) time.Time { return t0.Add(time.Duration(minutes) * time.Minute) }
+	label := func(kind, name string, minutes int) Event {
+		return Event{Kind: kind, Target: name, Timestamp: at(minutes)}
+	}
+
+	tests := []struct {
+		name   string
+		events []Event
+		want   []string
+	}{
+		{name: "no events"},
+		{
+			name: "added and kept",
+			events: []Event{
+				label(EventKindLabeled, "bug", 1),
+				{Kind: EventKindComment, Body: "thanks", Timestamp: at(2)},
+				label(EventKindLabeled, "p1", 3),
+			},
+			want: []string{"bug", "p1"},
+		},
+		{
+			name: "churn out of order",
+			events: []Event{
+				label(EventKindUnlabeled, "bug", 4),
+				label(EventKindLabeled, "bug", 1),
+				label(EventKindLabeled, "wip", 2),
+				label(EventKindLabeled, "bug", 5),
+				label(EventKindUnlabeled, "wip", 3),
+				label(EventKindLabeled, "wip", 6),
+				label(EventKindUnlabeled, "wip", 7),
+			},
+			want: []string{"bug"},
+		},
+		{
+			name: "re-added label moves to the end",
+			events: []Event{
+				label(EventKindLabeled, "a", 1),
+				label(EventKindLabeled, "b", 2),
+				label(EventKindUnlabeled, "a", 3),
+				label(EventKindLabeled, "a", 4),
+			},
+			want: []string{"b", "a"},
+		},
+		{
+			name: "same timestamp replays in given order",
+			events: []Event{
+				label(EventKindLabeled, "flaky", 1),
+				label(EventKindUnlabeled, "flaky", 1),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CurrentLabels(tt.events); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("CurrentLabels() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCoAuthors(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    []string
+	}{
+		{name: "none", message: "Fix typo\n\nSigned-off-by: Dev <dev@example.com>"},
+		{
+			name:    "single",
+			message: "Add retries\n\nCo-authored-by: Jane Doe <jane@example.com>",
+			want:    []string{"Jane Doe <jane@example.com>"},
+		},
+		{
+			name: "multiple with varied case and a duplicate",
+			message: "Pair on parser\n\nSome detail.\n\n" +
+				"Co-authored-by: Jane Doe <jane@example.com>\r\n" +
+				"co-authored-by:   Sam Lee <123+sam@users.noreply.github.com>\n" +
+				"Co-Authored-By: Jane Doe <jane@example.com>\n",
+			want: []string{"Jane Doe <jane@example.com>", "Sam Lee <123+sam@users.noreply.github.com>"},
+		},
+		{name: "mentioned in prose", message: "Drop the Co-authored-by check: it was noisy"},
+		{name: "empty trailer", message: "Oops\n\nCo-authored-by:"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := coAuthors(tt.message); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("coAuthors() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package prx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// ErrInvalidToken is returned by Verify when GitHub rejects the token.
+var ErrInvalidToken = errors.New("github token is invalid or expired")
+
+// integrationForbidden is the message GitHub sends when an installation token
+// calls an endpoint that only user tokens may use, such as GET /user.
+const integrationForbidden = "Resource not accessible by integration"
+
+// TokenInfo describes the token a Client authenticates with.
+type TokenInfo struct {
+	Login        string       `json:"login"`        // Account the token belongs to; empty for GitHub App installation tokens
+	Valid        bool         `json:"valid"`        // Whether GitHub accepted the token
+	Scopes       []string     `json:"scopes"`       // OAuth scopes; empty for fine-grained and GitHub App tokens
+	Capabilities Capabilities `json:"capabilities"` // Optional features the scopes allow
+}
+
+// Capabilities reports which optional enrichment features a token's scopes allow.
+// Tokens that do not report scopes (fine-grained and GitHub App tokens) are
+// assumed capable, since their access can only be discovered per request.
+type Capabilities struct {
+	PermissionResolution bool     `json:"permission_resolution"` // Collaborator permission lookups for write access (repo)
+	TeamExpansion        bool     `json:"team_expansion"`        // Reading team membership (read:org)
+	RequiredChecks       bool     `json:"required_checks"`       // Reading branch protection's required checks (repo)
+	ProjectInfo          bool     `json:"project_info"`          // Reading project boards (read:project)
+	Unavailable          []string `json:"unavailable,omitempty"` // Human-readable notes on what is unavailable and why
+}
+
+// capabilityScopes lists, per feature, the OAuth scopes that grant it.
+var capabilityScopes = []struct {
+	name   string
+	scopes []string
+	set    func(*Capabilities)
+}{
+	{"permission resolution", []string{"repo"}, func(c *Capabilities) { c.PermissionResolution = true }},
+	{"team expansion", []string{"read:org", "write:org", "admin:org"}, func(c *Capabilities) { c.TeamExpansion = true }},
+	{"required-check detection", []string{"repo"}, func(c *Capabilities) { c.RequiredChecks = true }},
+	{"project info", []string{"read:project", "project"}, func(c *Capabilities) { c.ProjectInfo = true }},
+}
+
+// capabilitiesFor derives capabilities from OAuth scopes. With no scopes reported,
+// every capability is assumed available.
+func capabilitiesFor(scopes []string) Capabilities {
+	var caps Capabilities
+	for _, feature := range capabilityScopes {
+		if len(scopes) == 0 || slices.ContainsFunc(feature.scopes, func(s string) bool { return slices.Contains(scopes, s) }) {
+			feature.set(&caps)
+			continue
+		}
+		caps.Unavailable = append(caps.Unavailable,
+			fmt.Sprintf("%s: unavailable due to scope (needs %s)", feature.name, strings.Join(feature.scopes, " or ")))
+	}
+	return caps
+}
+
+// Verify checks that the client's token is accepted by GitHub and reports the
+// account and scopes it carries. Call it before a long run to fail fast on a bad
+// token instead of hitting confusing 403s and 404s partway through. GitHub App
+// installation tokens, which cannot read GET /user, are reported valid with no login.
+//
+// Verify also records the token's capabilities on the client: features the scopes
+// do not allow are skipped afterwards instead of failing request by request. For
+// example, without the repo scope write access is not looked up via the API.
+func (c *Client) Verify(ctx context.Context) (*TokenInfo, error) {
+	var user githubUser
+	resp, err := c.github.get(ctx, "/user", &user)
+	var apiErr *GitHubAPIError
+	switch {
+	case err == nil:
+	case errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnauthorized:
+		c.logger.WarnContext(ctx, "github rejected token", "status", apiErr.Status)
+		return &TokenInfo{}, fmt.Errorf("%w: %s", ErrInvalidToken, apiErr.Status)
+	case errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusForbidden && strings.Contains(apiErr.Body, integrationForbidden):
+		// GitHub App installation tokens are accepted but belong to no user account.
+		c.logger.DebugContext(ctx, "token is a GitHub App installation token", "status", apiErr.Status)
+		resp = &githubResponse{}
+	default:
+		return nil, fmt.Errorf("verifying token: %w", err)
+	}
+
+	info := &TokenInfo{
+		Login:        user.Login,
+		Valid:        true,
+		Scopes:       resp.Scopes,
+		Capabilities: capabilitiesFor(resp.Scopes),
+	}
+	caps := info.Capabilities
+	c.capabilities.Store(&caps)
+	c.logger.InfoContext(ctx, "verified github token",
+		"login", info.Login,
+		"scopes", info.Scopes,
+		"unavailable", info.Capabilities.Unavailable)
+	return info, nil
+}
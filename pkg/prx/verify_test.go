@@ -0,0 +1,191 @@
+package prx
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestVerify(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		message    string
+		scopes     string
+		wantErr    error
+		wantValid  bool
+		wantLogin  string
+		wantScopes []string
+	}{
+		{
+			name:       "valid classic token",
+			status:     http.StatusOK,
+			scopes:     "repo, read:org",
+			wantValid:  true,
+			wantLogin:  "octocat",
+			wantScopes: []string{"repo", "read:org"},
+		},
+		{
+			name:      "valid fine-grained token without scopes header",
+			status:    http.StatusOK,
+			wantValid: true,
+			wantLogin: "octocat",
+		},
+		{
+			name:       "scopes with irregular spacing",
+			status:     http.StatusOK,
+			scopes:     " public_repo,,  gist ",
+			wantValid:  true,
+			wantLogin:  "octocat",
+			wantScopes: []string{"public_repo", "gist"},
+		},
+		{
+			name:    "invalid token",
+			status:  http.StatusUnauthorized,
+			message: "Bad credentials",
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:      "GitHub App installation token",
+			status:    http.StatusForbidden,
+			message:   "Resource not accessible by integration",
+			wantValid: true,
+		},
+		{
+			name:    "forbidden for another reason",
+			status:  http.StatusForbidden,
+			message: "Although you appear to have the correct authorization credentials, the organization has enabled SAML SSO.",
+			wantErr: &GitHubAPIError{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/user" {
+					http.NotFound(w, r)
+					return
+				}
+				if tt.scopes != "" {
+					w.Header().Set("X-OAuth-Scopes", tt.scopes)
+				}
+				if tt.status != http.StatusOK {
+					http.Error(w, `{"message":"`+tt.message+`"}`, tt.status)
+					return
+				}
+				if _, err := w.Write([]byte(`{"login":"octocat","type":"User"}`)); err != nil {
+					http.Error(w, err.Error(), http.StatusInternalServerError)
+				}
+			}))
+			defer server.Close()
+
+			info, err := newTestClient(t, server).Verify(context.Background())
+			var apiErr *GitHubAPIError
+			switch {
+			case errors.As(tt.wantErr, &apiErr):
+				if !errors.As(err, &apiErr) || info != nil {
+					t.Fatalf("Verify() = %+v, %v, want an API error", info, err)
+				}
+				return
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Verify() error = %v, want %v", err, tt.wantErr)
+				}
+				if info == nil || info.Valid {
+					t.Errorf("expected invalid token info, got %+v", info)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Verify() error = %v", err)
+			}
+			if info.Valid != tt.wantValid || info.Login != tt.wantLogin {
+				t.Errorf("Verify() = %+v, want valid=%v login=%q", info, tt.wantValid, tt.wantLogin)
+			}
+			if !slices.Equal(info.Scopes, tt.wantScopes) {
+				t.Errorf("scopes = %q, want %q", info.Scopes, tt.wantScopes)
+			}
+		})
+	}
+}
+
+func TestCapabilitiesFor(t *testing.T) {
+	tests := []struct {
+		name            string
+		scopes          []string
+		want            Capabilities
+		wantUnavailable int
+	}{
+		{
+			name:   "full classic token",
+			scopes: []string{"repo", "read:org", "read:project"},
+			want:   Capabilities{PermissionResolution: true, TeamExpansion: true, RequiredChecks: true, ProjectInfo: true},
+		},
+		{
+			name:            "public repo only",
+			scopes:          []string{"public_repo"},
+			wantUnavailable: 4,
+		},
+		{
+			name:            "repo without org access",
+			scopes:          []string{"repo"},
+			want:            Capabilities{PermissionResolution: true, RequiredChecks: true},
+			wantUnavailable: 2,
+		},
+		{
+			name:   "scopes not reported",
+			scopes: nil,
+			want:   Capabilities{PermissionResolution: true, TeamExpansion: true, RequiredChecks: true, ProjectInfo: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := capabilitiesFor(tt.scopes)
+			if len(got.Unavailable) != tt.wantUnavailable {
+				t.Errorf("Unavailable = %q, want %d entries", got.Unavailable, tt.wantUnavailable)
+			}
+			got.Unavailable = nil
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("capabilitiesFor(%q) = %+v, want %+v", tt.scopes, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifySkipsUnavailablePermissionChecks(t *testing.T) {
+	var permissionCalls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/user" {
+			permissionCalls++
+			http.Error(w, `{"message":"Resource not accessible"}`, http.StatusForbidden)
+			return
+		}
+		w.Header().Set("X-OAuth-Scopes", "public_repo")
+		if _, err := w.Write([]byte(`{"login":"octocat","type":"User"}`)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	info, err := client.Verify(context.Background())
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if info.Capabilities.PermissionResolution {
+		t.Fatal("public_repo token should not resolve permissions")
+	}
+
+	got := client.writeAccess(context.Background(), "owner", "repo", &githubUser{Login: "member"}, "MEMBER")
+	if got != WriteAccessUnknown {
+		t.Errorf("writeAccess() = %d, want %d", got, WriteAccessUnknown)
+	}
+	if permissionCalls != 0 {
+		t.Errorf("expected no permission API calls, got %d", permissionCalls)
+	}
+}